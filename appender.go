@@ -0,0 +1,315 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/marcboeker/go-duckdb/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// AppendError reports a row the Appender rejected, by its zero-based position
+// among all rows passed to Append. Rows appended before it are kept and the
+// Appender stays usable.
+type AppendError struct {
+	Index int64
+	Err   error
+}
+
+func (e *AppendError) Error() string {
+	return fmt.Sprintf("append row %d: %v", e.Index, e.Err)
+}
+
+func (e *AppendError) Unwrap() error {
+	return e.Err
+}
+
+// Appender loads rows into a table through DuckDB's appender, which is much
+// faster than INSERT for large loads. It holds one of the pool's connections
+// until Close, so other queries on db wait for it when the pool has a single
+// connection (the default).
+type Appender struct {
+	ctx        context.Context
+	conn       *sql.Conn
+	appender   *duckdb.Appender
+	db         *gorm.DB
+	columns    []string
+	flushEvery int
+	pending    int
+	rows       int64
+
+	schema     *schema.Schema
+	schemaType reflect.Type
+}
+
+// NewAppender opens an appender on table ("name" or "schema.name"). With
+// flushEvery > 0 the buffered rows are flushed after that many appends, which
+// bounds memory and makes earlier rows durable; otherwise they are flushed
+// when the internal chunk fills up and on Flush or Close.
+func NewAppender(db *gorm.DB, table string, flushEvery int) (*Appender, error) {
+	// Read the column order before taking the connection out of the pool
+	var described []describedColumn
+	if err := db.Raw("DESCRIBE " + db.Statement.Quote(table)).Scan(&described).Error; err != nil {
+		return nil, fmt.Errorf("failed to describe table %s: %w", table, err)
+	}
+	columns := make([]string, len(described))
+	for i, column := range described {
+		columns[i] = column.ColumnName
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("appender needs a connection pool, not a transaction: %w", err)
+	}
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+
+	schemaName, tableName := "", table
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		schemaName, tableName = table[:i], table[i+1:]
+	}
+
+	a := &Appender{ctx: ctx, conn: conn, db: db, columns: columns, flushEvery: flushEvery}
+	err = conn.Raw(func(driverConn interface{}) error {
+		if wrapped, ok := driverConn.(*convertingConn); ok {
+			driverConn = wrapped.Conn
+		}
+		dc, ok := driverConn.(driver.Conn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+		var err error
+		a.appender, err = duckdb.NewAppenderFromConn(dc, schemaName, tableName)
+		return err
+	})
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to create appender for %s: %w", table, err)
+	}
+	return a, nil
+}
+
+// Append adds one row. row is a model struct (or pointer to one) matched to
+// the table's columns by field name, a map[string]interface{} keyed by column,
+// or a []interface{} holding every column in table order. Columns without a
+// value are NULL, slices are appended as lists, and other values implementing
+// driver.Valuer are stored as the result of Value(). A rejected row yields an
+// *AppendError.
+func (a *Appender) Append(row interface{}) error {
+	index := a.rows
+	a.rows++
+
+	values, err := a.rowValues(row)
+	if err == nil {
+		err = a.appender.AppendRow(values...)
+	}
+	if err != nil {
+		return &AppendError{Index: index, Err: err}
+	}
+
+	if a.pending++; a.flushEvery > 0 && a.pending >= a.flushEvery {
+		return a.Flush()
+	}
+	return nil
+}
+
+// Flush writes the buffered rows to the table
+func (a *Appender) Flush() error {
+	a.pending = 0
+	if err := a.appender.Flush(); err != nil {
+		return fmt.Errorf("failed to flush appender: %w", err)
+	}
+	return nil
+}
+
+// Close flushes the remaining rows and returns the connection to the pool
+func (a *Appender) Close() error {
+	err := a.appender.Close()
+	if closeErr := a.conn.Close(); closeErr != nil {
+		err = errors.Join(err, closeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to close appender: %w", err)
+	}
+	return nil
+}
+
+func (a *Appender) rowValues(row interface{}) ([]driver.Value, error) {
+	values := make([]driver.Value, len(a.columns))
+
+	switch r := row.(type) {
+	case []interface{}:
+		if len(r) != len(a.columns) {
+			return nil, fmt.Errorf("row has %d values, table has %d columns", len(r), len(a.columns))
+		}
+		for i, value := range r {
+			values[i] = value
+		}
+	case map[string]interface{}:
+		for i, column := range a.columns {
+			values[i] = r[column]
+		}
+	default:
+		rv := reflect.Indirect(reflect.ValueOf(row))
+		if rv.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("cannot append %T", row)
+		}
+		if rv.Type() != a.schemaType {
+			stmt := &gorm.Statement{DB: a.db}
+			if err := stmt.Parse(row); err != nil {
+				return nil, err
+			}
+			a.schema, a.schemaType = stmt.Schema, rv.Type()
+		}
+		for i, column := range a.columns {
+			if field := a.schema.LookUpField(column); field != nil && field.DBName != "" {
+				values[i], _ = field.ValueOf(a.ctx, rv)
+			}
+		}
+	}
+
+	for i, value := range values {
+		converted, err := appenderValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", a.columns[i], err)
+		}
+		values[i] = converted
+	}
+	return values, nil
+}
+
+// appenderValue resolves pointers and driver.Valuer implementations to the
+// plain values the appender binds. Slices such as StringArray are appended as
+// lists element by element, since their Value() is a text literal that the
+// appender would not cast.
+func appenderValue(value interface{}) (driver.Value, error) {
+	if value == nil {
+		return nil, nil
+	}
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Slice) && rv.IsNil() {
+		return nil, nil
+	}
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			element, err := appenderValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			list[i] = element
+		}
+		return list, nil
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	if rv.Kind() == reflect.Ptr {
+		return appenderValue(rv.Elem().Interface())
+	}
+	return value, nil
+}
+
+// BulkAppend inserts every element of value, a slice of models or a pointer to
+// one, into the model's table through an Appender. It is far faster than
+// Create for large loads but bypasses GORM: hooks, associations and automatic
+// timestamps are not applied and nothing is read back with RETURNING. Zero
+// auto-increment primary keys are filled from the column's nextval default
+// before appending, so the elements hold the IDs they were stored with. If a
+// row is rejected, the rows before it may already be stored.
+func BulkAppend(db *gorm.DB, value interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(value))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("bulk append needs a slice of models, got %T", value)
+	}
+	if rv.Len() == 0 {
+		return nil
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(value); err != nil {
+		return fmt.Errorf("failed to parse %T: %w", value, err)
+	}
+	if err := fillAutoIncrement(db, stmt.Schema, rv); err != nil {
+		return err
+	}
+
+	appender, err := NewAppender(db, stmt.Schema.Table, 0)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := appender.Append(rv.Index(i).Interface()); err != nil {
+			return errors.Join(err, appender.Close())
+		}
+	}
+	return appender.Close()
+}
+
+// fillAutoIncrement sets zero auto-increment primary keys of the elements of
+// rv from the column's sequence, since the appender writes every column and
+// skips defaults
+func fillAutoIncrement(db *gorm.DB, s *schema.Schema, rv reflect.Value) error {
+	field := s.PrioritizedPrimaryField
+	if field == nil || !field.AutoIncrement {
+		return nil
+	}
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var missing []reflect.Value
+	for i := 0; i < rv.Len(); i++ {
+		element := reflect.Indirect(rv.Index(i))
+		if _, zero := field.ValueOf(ctx, element); zero {
+			missing = append(missing, element)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if !rv.Index(0).CanAddr() && rv.Index(0).Kind() != reflect.Ptr {
+		return fmt.Errorf("bulk append needs a pointer to the slice to set %s", field.Name)
+	}
+
+	var columns []struct {
+		ColumnName string
+		Default    *string
+	}
+	if err := db.Raw("DESCRIBE " + db.Statement.Quote(s.Table)).Scan(&columns).Error; err != nil {
+		return fmt.Errorf("failed to describe table %s: %w", s.Table, err)
+	}
+	var nextval string
+	for _, column := range columns {
+		if column.ColumnName == field.DBName && column.Default != nil && strings.HasPrefix(*column.Default, "nextval(") {
+			nextval = *column.Default
+		}
+	}
+	if nextval == "" {
+		return fmt.Errorf("column %s of %s has no sequence default to draw %s from", field.DBName, s.Table, field.Name)
+	}
+
+	var ids []int64
+	if err := db.Raw("SELECT "+nextval+" FROM range(?)", len(missing)).Scan(&ids).Error; err != nil {
+		return fmt.Errorf("failed to draw %d ids for %s: %w", len(missing), s.Table, err)
+	}
+	for i, element := range missing {
+		if err := field.Set(ctx, element, ids[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
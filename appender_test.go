@@ -0,0 +1,166 @@
+package duckdb_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+func TestAppender_RecoversFromBadRow(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE readings (id BIGINT, sensor VARCHAR, value INTEGER)").Error)
+
+	appender, err := duckdb.NewAppender(db, "readings", 100000)
+	require.NoError(t, err)
+
+	const total, bad = 1000000, 654321
+	var failures []*duckdb.AppendError
+	for i := 0; i < total; i++ {
+		row := []interface{}{int64(i), "s", int32(i % 1000)}
+		if i == bad {
+			row[2] = "not a number"
+		}
+		if err := appender.Append(row); err != nil {
+			var appendErr *duckdb.AppendError
+			require.True(t, errors.As(err, &appendErr), "unexpected error: %v", err)
+			failures = append(failures, appendErr)
+		}
+	}
+	require.NoError(t, appender.Close())
+
+	require.Len(t, failures, 1)
+	assert.Equal(t, int64(bad), failures[0].Index)
+	assert.Contains(t, failures[0].Error(), fmt.Sprintf("append row %d", bad))
+
+	var count, missing int64
+	require.NoError(t, db.Raw("SELECT count(*) FROM readings").Scan(&count).Error)
+	assert.Equal(t, int64(total-1), count)
+	require.NoError(t, db.Raw("SELECT count(*) FROM readings WHERE id = ?", bad).Scan(&missing).Error)
+	assert.Zero(t, missing)
+}
+
+func TestAppender_Models(t *testing.T) {
+	db := setupTestDB(t)
+
+	type Shipment struct {
+		ID        uint `gorm:"primaryKey"`
+		Reference string
+		Tags      duckdb.StringArray
+		Weight    *float64
+		ShippedAt time.Time
+		Meta      duckdb.JSONType
+	}
+	require.NoError(t, db.AutoMigrate(&Shipment{}))
+
+	appender, err := duckdb.NewAppender(db, "shipments", 0)
+	require.NoError(t, err)
+
+	weight := 12.5
+	shipped := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	require.NoError(t, appender.Append(&Shipment{ID: 1, Reference: "A-1", Tags: duckdb.StringArray{"fragile", "express"}, Weight: &weight, ShippedAt: shipped, Meta: duckdb.NewJSON(map[string]interface{}{"carrier": "dhl"})}))
+	require.NoError(t, appender.Append(Shipment{ID: 2, Reference: "A-2", ShippedAt: shipped}))
+	require.NoError(t, appender.Append(map[string]interface{}{"id": 3, "reference": "A-3"}))
+	require.NoError(t, appender.Close())
+
+	var shipments []Shipment
+	require.NoError(t, db.Order("id").Find(&shipments).Error)
+	require.Len(t, shipments, 3)
+	assert.Equal(t, duckdb.StringArray{"fragile", "express"}, shipments[0].Tags)
+	require.NotNil(t, shipments[0].Weight)
+	assert.Equal(t, weight, *shipments[0].Weight)
+	assert.True(t, shipped.Equal(shipments[0].ShippedAt))
+	assert.Equal(t, "dhl", shipments[0].Meta.Data.(map[string]interface{})["carrier"])
+	assert.Nil(t, shipments[1].Weight)
+	assert.Equal(t, "A-3", shipments[2].Reference)
+
+	_, err = duckdb.NewAppender(db, "missing_table", 0)
+	assert.Error(t, err)
+}
+
+type BulkEvent struct {
+	ID     uint `gorm:"primaryKey"`
+	Kind   string
+	Amount float64
+	Tags   duckdb.StringArray
+}
+
+func TestBulkAppend(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&BulkEvent{}))
+
+	// IDs continue the sequence used by Create
+	require.NoError(t, db.Create(&BulkEvent{Kind: "created"}).Error)
+
+	events := []BulkEvent{
+		{Kind: "click", Amount: 1.5, Tags: duckdb.StringArray{"web"}},
+		{Kind: "view", Amount: 2},
+		{ID: 100, Kind: "explicit"},
+	}
+	require.NoError(t, duckdb.BulkAppend(db, &events))
+	assert.Equal(t, uint(2), events[0].ID)
+	assert.Equal(t, uint(3), events[1].ID)
+	assert.Equal(t, uint(100), events[2].ID)
+
+	pointers := []*BulkEvent{{Kind: "pointer"}}
+	require.NoError(t, duckdb.BulkAppend(db, pointers))
+	assert.Equal(t, uint(4), pointers[0].ID)
+
+	var stored []BulkEvent
+	require.NoError(t, db.Order("id").Find(&stored).Error)
+	require.Len(t, stored, 5)
+	assert.Equal(t, []string{"created", "click", "view", "pointer", "explicit"},
+		[]string{stored[0].Kind, stored[1].Kind, stored[2].Kind, stored[3].Kind, stored[4].Kind})
+	assert.Equal(t, duckdb.StringArray{"web"}, stored[1].Tags)
+
+	require.NoError(t, duckdb.BulkAppend(db, []BulkEvent{}))
+	require.Error(t, duckdb.BulkAppend(db, BulkEvent{Kind: "single"}))
+}
+
+func benchmarkEvents(n int) []BulkEvent {
+	events := make([]BulkEvent, n)
+	for i := range events {
+		events[i] = BulkEvent{Kind: "bench", Amount: float64(i)}
+	}
+	return events
+}
+
+func setupBulkBenchmarkDB(b *testing.B) *gorm.DB {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(b, err)
+	require.NoError(b, db.AutoMigrate(&BulkEvent{}))
+	return db
+}
+
+func BenchmarkBulkAppend(b *testing.B) {
+	db := setupBulkBenchmarkDB(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		events := benchmarkEvents(1000)
+		if err := duckdb.BulkAppend(db, &events); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCreateLoop(b *testing.B) {
+	db := setupBulkBenchmarkDB(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		events := benchmarkEvents(1000)
+		for j := range events {
+			if err := db.Create(&events[j]).Error; err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+
+	"gorm.io/gorm/clause"
 )
 
 // formatSliceForDuckDB converts a Go slice to DuckDB array literal syntax
@@ -27,8 +29,9 @@ func formatSliceForDuckDB(value interface{}) (string, error) {
 		case reflect.Float32, reflect.Float64:
 			elements = append(elements, fmt.Sprintf("%g", elem.Float()))
 		case reflect.String:
-			// Escape single quotes in strings
-			str := strings.ReplaceAll(elem.String(), "'", "''")
+			// The literal is bound as a string and cast, so DuckDB's list parser
+			// reads it: quotes and backslashes are escaped with a backslash
+			str := strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(elem.String())
 			elements = append(elements, fmt.Sprintf("'%s'", str))
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			elements = append(elements, fmt.Sprintf("%d", elem.Int()))
@@ -62,6 +65,35 @@ func (al ArrayLiteral) Value() (driver.Value, error) {
 	return formatSliceForDuckDB(al.Data)
 }
 
+// listParam binds a Go slice as a single list parameter cast to a DuckDB list
+// type. The cast comes from GormDataType when the slice has one (StringArray,
+// IntArray, ...) and from the element kind otherwise.
+func listParam(values interface{}) clause.Expr {
+	return clause.Expr{SQL: "?::" + listTypeOf(values), Vars: []interface{}{ArrayLiteral{Data: values}}}
+}
+
+func listTypeOf(values interface{}) string {
+	if typed, ok := values.(interface{ GormDataType() string }); ok {
+		return typed.GormDataType()
+	}
+
+	t := reflect.TypeOf(values)
+	if t == nil || t.Kind() != reflect.Slice {
+		return "VARCHAR[]"
+	}
+	switch t.Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "BIGINT[]"
+	case reflect.Float32, reflect.Float64:
+		return "DOUBLE[]"
+	case reflect.Bool:
+		return "BOOLEAN[]"
+	default:
+		return "VARCHAR[]"
+	}
+}
+
 // SimpleArrayScanner provides basic array scanning functionality
 type SimpleArrayScanner struct {
 	Target interface{} // Pointer to slice
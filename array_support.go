@@ -1,10 +1,20 @@
 package duckdb
 
 import (
+	"cmp"
+	"context"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+
+	"github.com/marcboeker/go-duckdb/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Helper function to parse array string representation
@@ -34,337 +44,818 @@ func parseArrayString(s string) []string {
 	return result
 }
 
-// StringArray represents a DuckDB TEXT[] array type
-type StringArray []string
+// Array is a DuckDB list of T. string, int64, float64 and bool elements are
+// built in and have the names StringArray, IntArray, FloatArray and BoolArray;
+// any other T must implement ArrayElementType.
+type Array[T any] []T
 
-// Value implements driver.Valuer interface for StringArray
-func (a StringArray) Value() (driver.Value, error) {
-	if a == nil {
-		return "[]", nil
+// StringArray represents a DuckDB VARCHAR[] array type
+type StringArray = Array[string]
+
+// IntArray represents a DuckDB BIGINT[] array type
+type IntArray = Array[int64]
+
+// FloatArray represents a DuckDB DOUBLE[] array type
+type FloatArray = Array[float64]
+
+// BoolArray represents a DuckDB BOOLEAN[] array type
+type BoolArray = Array[bool]
+
+// ArrayElement converts the elements of an Array[T]. SQLType is the DuckDB
+// element type used for GormDataType. FromDriver converts an element of a
+// list returned by the driver and Parse one element of DuckDB's list text
+// (null reports an unquoted NULL). Compare orders elements for Sort and
+// Dedup. Format, if set, renders an element inside a list literal; otherwise
+// strings, numbers and booleans are rendered by kind.
+type ArrayElement[T any] struct {
+	SQLType    string
+	FromDriver func(value interface{}) (T, error)
+	Parse      func(text string, null bool) (T, error)
+	Compare    func(a, b T) int
+	Format     func(value T) string
+}
+
+// ArrayElementType makes Array[T] usable for another element type. It is
+// called on the zero value of T, e.g.
+//
+//	type Score int32
+//
+//	func (Score) ArrayElement() duckdb.ArrayElement[Score] {
+//		return duckdb.ArrayElement[Score]{SQLType: "INTEGER", ...}
+//	}
+type ArrayElementType[T any] interface {
+	ArrayElement() ArrayElement[T]
+}
+
+// arrayElementOf returns the element conversion for T: a built-in one for
+// string, int64, float64 and bool, or the one T provides through
+// ArrayElementType.
+func arrayElementOf[T any]() (ArrayElement[T], error) {
+	var zero T
+	var element interface{}
+	switch v := any(zero).(type) {
+	case string:
+		element = stringArrayElement
+	case int64:
+		element = intArrayElement
+	case float64:
+		element = floatArrayElement
+	case bool:
+		element = boolArrayElement
+	case ArrayElementType[T]:
+		return v.ArrayElement(), nil
+	default:
+		return ArrayElement[T]{}, fmt.Errorf("array element %T is not built in and does not implement ArrayElementType", zero)
 	}
+	return element.(ArrayElement[T]), nil
+}
 
+var stringArrayElement = ArrayElement[string]{
+	SQLType: "VARCHAR",
+	FromDriver: func(value interface{}) (string, error) {
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case nil:
+			return "", nil
+		}
+		return "", fmt.Errorf("not a string")
+	},
+	// NULL elements read as "", as they do from a native list
+	Parse: func(text string, null bool) (string, error) {
+		if null {
+			return "", nil
+		}
+		return text, nil
+	},
+	Compare: cmp.Compare[string],
+}
+
+var intArrayElement = ArrayElement[int64]{
+	SQLType: "BIGINT",
+	FromDriver: func(value interface{}) (int64, error) {
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case int:
+			return int64(v), nil
+		case float64:
+			return int64(v), nil
+		}
+		return parseArrayNumber(fmt.Sprint(value), func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) })
+	},
+	Parse: func(text string, null bool) (int64, error) {
+		return parseArrayNumber(text, func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) })
+	},
+	Compare: cmp.Compare[int64],
+}
+
+var floatArrayElement = ArrayElement[float64]{
+	SQLType: "DOUBLE",
+	FromDriver: func(value interface{}) (float64, error) {
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case int:
+			return float64(v), nil
+		}
+		return parseArrayNumber(fmt.Sprint(value), func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+	},
+	Parse: func(text string, null bool) (float64, error) {
+		return parseArrayNumber(text, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+	},
+	// cmp.Compare treats NaNs as equal and orders them first
+	Compare: cmp.Compare[float64],
+}
+
+var boolArrayElement = ArrayElement[bool]{
+	SQLType: "BOOLEAN",
+	FromDriver: func(value interface{}) (bool, error) {
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		return false, fmt.Errorf("not a boolean")
+	},
+	Parse: func(text string, null bool) (bool, error) {
+		switch {
+		case !null && strings.EqualFold(text, "true"):
+			return true, nil
+		case !null && strings.EqualFold(text, "false"):
+			return false, nil
+		}
+		return false, fmt.Errorf("cannot parse '%s' as boolean", text)
+	},
+	Compare: func(a, b bool) int {
+		switch {
+		case a == b:
+			return 0
+		case !a:
+			return -1
+		}
+		return 1
+	},
+}
+
+func parseArrayNumber[N int64 | float64](text string, parse func(string) (N, error)) (N, error) {
+	n, err := parse(strings.TrimSpace(text))
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse '%s' as %T", text, n)
+	}
+	return n, nil
+}
+
+// Value implements driver.Valuer interface for Array. It returns a DuckDB list
+// literal such as ['a', 'it\'s'], the same form used for plain Go slices, so
+// values compare equal to stored lists.
+func (a Array[T]) Value() (driver.Value, error) {
 	if len(a) == 0 {
 		return "[]", nil
 	}
 
-	// Use JSON format for consistency with tests
-	jsonBytes, err := json.Marshal([]string(a))
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal StringArray to JSON: %w", err)
+	element, err := arrayElementOf[T]()
+	if err != nil || element.Format == nil {
+		return formatSliceForDuckDB([]T(a))
 	}
-
-	return string(jsonBytes), nil
+	elements := make([]string, len(a))
+	for i, v := range a {
+		elements[i] = element.Format(v)
+	}
+	return "[" + strings.Join(elements, ", ") + "]", nil
 }
 
-// Scan implements sql.Scanner interface for StringArray
-func (a *StringArray) Scan(value interface{}) error {
+// Scan implements sql.Scanner interface for Array. It accepts lists from the
+// driver, JSON arrays, and DuckDB's own list text, e.g. [a, 'x,y', 'it\'s']
+// from a list cast to VARCHAR.
+func (a *Array[T]) Scan(value interface{}) error {
 	if value == nil {
 		*a = nil
 		return nil
 	}
 
+	element, err := arrayElementOf[T]()
+	if err != nil {
+		return err
+	}
+
 	switch v := value.(type) {
 	case string:
-		return a.scanFromString(v)
+		return a.scanFromString(element, v)
 	case []byte:
-		return a.scanFromString(string(v))
+		return a.scanFromString(element, string(v))
 	case []interface{}:
-		return a.scanFromSlice(v)
-	case []string:
-		*a = StringArray(v)
+		result := make(Array[T], len(v))
+		for i, item := range v {
+			if result[i], err = element.FromDriver(item); err != nil {
+				return fmt.Errorf("cannot convert element %d of type %T: %w", i, item, err)
+			}
+		}
+		*a = result
+		return nil
+	case []T:
+		*a = Array[T](v)
 		return nil
 	default:
-		return fmt.Errorf("cannot scan %T into StringArray", value)
+		return fmt.Errorf("cannot scan %T into %T", value, *a)
 	}
 }
 
-func (a *StringArray) scanFromString(s string) error {
+func (a *Array[T]) scanFromString(element ArrayElement[T], s string) error {
 	s = strings.TrimSpace(s)
 
 	// Handle empty array
 	if s == "[]" || s == "" {
-		*a = StringArray{}
+		*a = Array[T]{}
 		return nil
 	}
 
-	// If it looks like JSON (starts with [), try JSON parsing first
-	if strings.HasPrefix(s, "[") {
-		var jsonArray []string
-		if err := json.Unmarshal([]byte(s), &jsonArray); err != nil {
-			// If it looks like JSON but fails to parse, return error
-			return fmt.Errorf("invalid JSON array format: %w", err)
-		}
-		*a = StringArray(jsonArray)
+	var jsonArray []T
+	if err := json.Unmarshal([]byte(s), &jsonArray); err == nil {
+		*a = Array[T](jsonArray)
 		return nil
 	}
 
-	// Fallback to DuckDB array format parsing
-	// Remove brackets if present
-	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
-		s = s[1 : len(s)-1]
-	}
-
-	if strings.TrimSpace(s) == "" {
-		*a = StringArray{}
-		return nil
+	elements, err := splitListLiteral(s)
+	if err != nil {
+		return err
 	}
-
-	// Simple CSV parsing - this could be enhanced for complex cases
-	parts := strings.Split(s, ",")
-	result := make(StringArray, 0, len(parts))
-
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		// Remove quotes if present
-		if strings.HasPrefix(part, "'") && strings.HasSuffix(part, "'") {
-			part = part[1 : len(part)-1]
-			// Unescape single quotes
-			part = strings.ReplaceAll(part, "''", "'")
-		} else if strings.HasPrefix(part, "\"") && strings.HasSuffix(part, "\"") {
-			part = part[1 : len(part)-1]
-			// Unescape double quotes
-			part = strings.ReplaceAll(part, "\"\"", "\"")
+	result := make(Array[T], len(elements))
+	for i, e := range elements {
+		if result[i], err = element.Parse(e.text, e.null); err != nil {
+			return err
 		}
-		result = append(result, part)
 	}
-
 	*a = result
 	return nil
 }
 
-func (a *StringArray) scanFromSlice(slice []interface{}) error {
-	result := make(StringArray, 0, len(slice))
-	for i, item := range slice {
-		switch v := item.(type) {
-		case string:
-			result = append(result, v)
-		case nil:
-			result = append(result, "")
-		default:
-			return fmt.Errorf("cannot convert element %d of type %T to string", i, item)
-		}
+// GormDataType implements the GormDataTypeInterface for Array. It panics
+// when T is neither built in nor an ArrayElementType, since such an array
+// has no column type.
+func (Array[T]) GormDataType() string {
+	element, err := arrayElementOf[T]()
+	if err != nil {
+		panic("duckdb: " + err.Error())
 	}
-	*a = result
-	return nil
+	return element.SQLType + "[]"
 }
 
-// IntArray represents a DuckDB INTEGER[] array type
-type IntArray []int64
+// Sort returns an ascending copy of the array, leaving a untouched. Sorting
+// before insert gives arrays a canonical form for comparison and dedup.
+func (a Array[T]) Sort() Array[T] {
+	return a.sorted(false)
+}
 
-// Value implements driver.Valuer interface for IntArray
-func (a IntArray) Value() (driver.Value, error) {
+// SortDesc returns a descending copy of the array
+func (a Array[T]) SortDesc() Array[T] {
+	return a.sorted(true)
+}
+
+func (a Array[T]) sorted(desc bool) Array[T] {
 	if a == nil {
-		return "[]", nil
+		return nil
 	}
-
-	if len(a) == 0 {
-		return "[]", nil
+	sorted := slices.Clone(a)
+	if element, err := arrayElementOf[T](); err == nil {
+		slices.SortStableFunc(sorted, func(x, y T) int {
+			if desc {
+				return element.Compare(y, x)
+			}
+			return element.Compare(x, y)
+		})
 	}
+	return sorted
+}
 
-	// Use JSON format for consistency
-	jsonBytes, err := json.Marshal([]int64(a))
+// Dedup returns a copy of the array without repeated elements, keeping the
+// first occurrence of each in its original position. Elements are equal when
+// the element Compare returns 0, so NaNs count as equal.
+func (a Array[T]) Dedup() Array[T] {
+	if a == nil {
+		return nil
+	}
+	element, err := arrayElementOf[T]()
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal IntArray to JSON: %w", err)
+		return slices.Clone(a)
+	}
+
+	// Sort positions by value; the first position of each run of equal values
+	// is the occurrence to keep
+	order := make([]int, len(a))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int { return element.Compare(a[i], a[j]) })
+	keep := make([]bool, len(a))
+	for i, position := range order {
+		if i == 0 || element.Compare(a[order[i-1]], a[position]) != 0 {
+			keep[position] = true
+		}
+	}
+
+	deduped := make(Array[T], 0, len(a))
+	for i, v := range a {
+		if keep[i] {
+			deduped = append(deduped, v)
+		}
 	}
+	return deduped
+}
+
+// Matrix is a rectangular two-dimensional DuckDB list (T[][]) whose rows all
+// have the same length. Elements convert as in Array[T].
+type Matrix[T any] [][]T
+
+// IntMatrix represents a DuckDB BIGINT[][] column
+type IntMatrix = Matrix[int64]
+
+// FloatMatrix represents a DuckDB DOUBLE[][] column
+type FloatMatrix = Matrix[float64]
 
-	return string(jsonBytes), nil
+// Value implements driver.Valuer interface for Matrix, returning a nested list
+// literal such as [[1, 2], [3, 4]]
+func (m Matrix[T]) Value() (driver.Value, error) {
+	if err := m.checkRectangular(); err != nil {
+		return nil, err
+	}
+
+	rows := make([]string, len(m))
+	for i, row := range m {
+		value, err := Array[T](row).Value()
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = value.(string)
+	}
+	return "[" + strings.Join(rows, ", ") + "]", nil
 }
 
-// Scan implements sql.Scanner interface for IntArray
-func (a *IntArray) Scan(value interface{}) error {
+// Scan implements sql.Scanner interface for Matrix. It accepts nested lists
+// from the driver, JSON, and DuckDB's nested list text.
+func (m *Matrix[T]) Scan(value interface{}) error {
 	if value == nil {
-		*a = nil
+		*m = nil
 		return nil
 	}
 
+	var rows []interface{}
 	switch v := value.(type) {
-	case string:
-		return a.scanFromString(v)
-	case []byte:
-		return a.scanFromString(string(v))
-	case []interface{}:
-		return a.scanFromSlice(v)
-	case []int64:
-		*a = IntArray(v)
+	case [][]T:
+		if err := Matrix[T](v).checkRectangular(); err != nil {
+			return err
+		}
+		*m = Matrix[T](v)
 		return nil
+	case []interface{}:
+		rows = v
+	case string, []byte:
+		s := strings.TrimSpace(fmt.Sprintf("%s", v))
+		var jsonMatrix [][]T
+		if err := json.Unmarshal([]byte(s), &jsonMatrix); err == nil {
+			for _, row := range jsonMatrix {
+				if row == nil {
+					rows = append(rows, nil)
+				} else {
+					rows = append(rows, row)
+				}
+			}
+			break
+		}
+		elements, err := splitListLiteral(s)
+		if err != nil {
+			return err
+		}
+		rows = make([]interface{}, len(elements))
+		for i, element := range elements {
+			if !element.null {
+				rows[i] = element.text
+			}
+		}
 	default:
-		return fmt.Errorf("cannot scan %T into IntArray", value)
+		return fmt.Errorf("cannot scan %T into %T", value, *m)
+	}
+
+	result := make(Matrix[T], len(rows))
+	for i, row := range rows {
+		if row == nil {
+			return fmt.Errorf("matrix row %d is NULL", i)
+		}
+		var array Array[T]
+		if err := array.Scan(row); err != nil {
+			return fmt.Errorf("matrix row %d: %w", i, err)
+		}
+		result[i] = array
 	}
+	if err := result.checkRectangular(); err != nil {
+		return err
+	}
+	*m = result
+	return nil
+}
+
+func (m Matrix[T]) checkRectangular() error {
+	for i, row := range m {
+		if len(row) != len(m[0]) {
+			return fmt.Errorf("ragged matrix: row %d has %d elements, row 0 has %d", i, len(row), len(m[0]))
+		}
+	}
+	return nil
 }
 
-func (a *IntArray) scanFromString(s string) error {
+// GormDataType implements the GormDataTypeInterface for Matrix
+func (Matrix[T]) GormDataType() string {
+	return (Array[T]{}).GormDataType() + "[]"
+}
+
+// listElement is one element of a DuckDB list literal
+type listElement struct {
+	text string
+	null bool
+}
+
+// splitListLiteral parses DuckDB's list text form. Quoted elements use
+// backslash escapes; unquoted ones are trimmed and taken literally, with an
+// unquoted NULL marking a NULL element. Brackets nest inside unquoted elements.
+func splitListLiteral(s string) ([]listElement, error) {
 	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("invalid list literal %q", s)
+	}
+	body := s[1 : len(s)-1]
+	if strings.TrimSpace(body) == "" {
+		return []listElement{}, nil
+	}
+
+	var (
+		elements []listElement
+		current  strings.Builder
+		quoted   bool // current element was quoted
+		inQuote  bool
+		escaped  bool
+		depth    int
+	)
+	finish := func() {
+		text := current.String()
+		if !quoted {
+			text = strings.TrimSpace(text)
+		}
+		elements = append(elements, listElement{text: text, null: !quoted && strings.EqualFold(text, "NULL")})
+		current.Reset()
+		quoted = false
+	}
+
+	for _, r := range body {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case inQuote && r == '\\':
+			escaped = true
+		case inQuote && r == '\'':
+			inQuote = false
+		case inQuote:
+			current.WriteRune(r)
+		case r == '\'' && depth == 0 && strings.TrimSpace(current.String()) == "":
+			current.Reset()
+			inQuote, quoted = true, true
+		case r == ',' && depth == 0:
+			finish()
+		case r == '[' || r == '{' || r == '(':
+			depth++
+			current.WriteRune(r)
+		case r == ']' || r == '}' || r == ')':
+			depth--
+			current.WriteRune(r)
+		case quoted:
+			// Only whitespace may follow a closing quote
+			if r != ' ' && r != '\t' && r != '\n' {
+				return nil, fmt.Errorf("invalid list literal %q", s)
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuote || depth != 0 {
+		return nil, fmt.Errorf("invalid list literal %q", s)
+	}
+	finish()
+	return elements, nil
+}
 
-	if s == "[]" || s == "" {
-		*a = IntArray{}
-		return nil
+// DistinctStringArray is a StringArray that drops repeated elements when
+// written, e.g. for tag lists. Values read back are used as stored.
+type DistinctStringArray StringArray
+
+// Value implements driver.Valuer, deduplicating before conversion
+func (a DistinctStringArray) Value() (driver.Value, error) {
+	return StringArray(a).Dedup().Value()
+}
+
+// Scan implements sql.Scanner interface for DistinctStringArray
+func (a *DistinctStringArray) Scan(value interface{}) error {
+	return (*StringArray)(a).Scan(value)
+}
+
+// GormDataType implements the GormDataTypeInterface for DistinctStringArray
+func (DistinctStringArray) GormDataType() string {
+	return StringArray{}.GormDataType()
+}
+
+// DistinctIntArray is an IntArray that drops repeated elements when written
+type DistinctIntArray IntArray
+
+// Value implements driver.Valuer, deduplicating before conversion
+func (a DistinctIntArray) Value() (driver.Value, error) {
+	return IntArray(a).Dedup().Value()
+}
+
+// Scan implements sql.Scanner interface for DistinctIntArray
+func (a *DistinctIntArray) Scan(value interface{}) error {
+	return (*IntArray)(a).Scan(value)
+}
+
+// GormDataType implements the GormDataTypeInterface for DistinctIntArray
+func (DistinctIntArray) GormDataType() string {
+	return IntArray{}.GormDataType()
+}
+
+// DistinctFloatArray is a FloatArray that drops repeated elements when written
+type DistinctFloatArray FloatArray
+
+// Value implements driver.Valuer, deduplicating before conversion
+func (a DistinctFloatArray) Value() (driver.Value, error) {
+	return FloatArray(a).Dedup().Value()
+}
+
+// Scan implements sql.Scanner interface for DistinctFloatArray
+func (a *DistinctFloatArray) Scan(value interface{}) error {
+	return (*FloatArray)(a).Scan(value)
+}
+
+// GormDataType implements the GormDataTypeInterface for DistinctFloatArray
+func (DistinctFloatArray) GormDataType() string {
+	return FloatArray{}.GormDataType()
+}
+
+// EnumArray represents a DuckDB array of a named ENUM type (e.g. mood[]).
+// The enum type itself must exist (CREATE TYPE mood AS ENUM (...)) before
+// the column is migrated; tag the field with `gorm:"type:mood[]"`.
+type EnumArray struct {
+	Name     string   `json:"name"`     // Enum type name
+	Values   []string `json:"values"`   // Allowed enum values
+	Selected []string `json:"selected"` // Current selected values
+}
+
+// NewEnumArray creates a new EnumArray with allowed values
+func NewEnumArray(name string, values []string, selected ...string) EnumArray {
+	return EnumArray{
+		Name:     name,
+		Values:   values,
+		Selected: selected,
 	}
+}
 
-	// Try JSON unmarshaling first
-	var jsonArray []int64
-	if err := json.Unmarshal([]byte(s), &jsonArray); err == nil {
-		*a = IntArray(jsonArray)
-		return nil
+// Value implements driver.Valuer interface for EnumArray
+func (e EnumArray) Value() (driver.Value, error) {
+	if e.Selected == nil {
+		return nil, nil
 	}
 
-	// Fallback to custom parsing
-	parts := parseArrayString(s)
+	if len(e.Values) > 0 {
+		for _, s := range e.Selected {
+			if !e.allows(s) {
+				return nil, fmt.Errorf("invalid enum value: %s not in %v", s, e.Values)
+			}
+		}
+	}
 
-	if len(parts) == 0 {
-		*a = IntArray{}
+	return formatSliceForDuckDB(e.Selected)
+}
+
+// Scan implements sql.Scanner interface for EnumArray
+func (e *EnumArray) Scan(value interface{}) error {
+	if value == nil {
+		e.Selected = nil
 		return nil
 	}
 
-	result := make(IntArray, 0, len(parts))
-	for _, part := range parts {
-		var i int64
-		if _, err := fmt.Sscanf(part, "%d", &i); err != nil {
-			return fmt.Errorf("cannot parse '%s' as integer: %w", part, err)
-		}
-		result = append(result, i)
+	var arr StringArray
+	if err := arr.Scan(value); err != nil {
+		return fmt.Errorf("cannot scan %T into EnumArray: %w", value, err)
 	}
-
-	*a = result
+	e.Selected = []string(arr)
 	return nil
 }
 
-func (a *IntArray) scanFromSlice(slice []interface{}) error {
-	result := make(IntArray, 0, len(slice))
-	for _, item := range slice {
-		switch v := item.(type) {
-		case int64:
-			result = append(result, v)
-		case int:
-			result = append(result, int64(v))
-		case float64:
-			result = append(result, int64(v))
-		default:
-			var i int64
-			if _, err := fmt.Sscanf(fmt.Sprintf("%v", item), "%d", &i); err != nil {
-				return fmt.Errorf("cannot convert %T to int64: %w", item, err)
-			}
-			result = append(result, i)
+// IsValid checks that every selected value is an allowed enum value
+func (e EnumArray) IsValid() bool {
+	for _, s := range e.Selected {
+		if !e.allows(s) {
+			return false
 		}
 	}
-	*a = result
-	return nil
+	return true
 }
 
-// FloatArray represents a DuckDB DOUBLE[] array type
-type FloatArray []float64
+func (e EnumArray) allows(value string) bool {
+	for _, v := range e.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
 
-// Value implements driver.Valuer interface for FloatArray
-func (a FloatArray) Value() (driver.Value, error) {
-	if a == nil {
-		return "[]", nil
+// GormDataType implements the GormDataTypeInterface for EnumArray
+func (e EnumArray) GormDataType() string {
+	if e.Name != "" {
+		return e.Name + "[]"
 	}
+	return "VARCHAR[]"
+}
 
-	if len(a) == 0 {
-		return "[]", nil
+// DecimalArray represents a DuckDB DECIMAL(p,s)[] array. Elements are kept as
+// strings so values such as "2.50" round-trip exactly. Declare the column with
+// `gorm:"precision:18;scale:2"` or `gorm:"type:DECIMAL(18,2)[]"`.
+type DecimalArray struct {
+	Elements  []string `json:"elements"`
+	Precision int      `json:"precision"`
+	Scale     int      `json:"scale"`
+}
+
+var decimalLiteral = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)$`)
+
+// NewDecimalArray creates a DecimalArray of DECIMAL(precision,scale) elements
+func NewDecimalArray(precision, scale int, elements ...string) DecimalArray {
+	return DecimalArray{
+		Elements:  elements,
+		Precision: precision,
+		Scale:     scale,
 	}
+}
 
-	// Use JSON format for consistency
-	jsonBytes, err := json.Marshal([]float64(a))
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal FloatArray to JSON: %w", err)
+// Value implements driver.Valuer interface for DecimalArray
+func (a DecimalArray) Value() (driver.Value, error) {
+	if a.Elements == nil {
+		return nil, nil
 	}
 
-	return string(jsonBytes), nil
+	for _, e := range a.Elements {
+		if !decimalLiteral.MatchString(e) {
+			return nil, fmt.Errorf("invalid decimal value: %q", e)
+		}
+	}
+	return "[" + strings.Join(a.Elements, ", ") + "]", nil
 }
 
-// Scan implements sql.Scanner interface for FloatArray
-func (a *FloatArray) Scan(value interface{}) error {
+// Scan implements sql.Scanner interface for DecimalArray
+func (a *DecimalArray) Scan(value interface{}) error {
 	if value == nil {
-		*a = nil
+		a.Elements = nil
 		return nil
 	}
 
 	switch v := value.(type) {
 	case string:
-		return a.scanFromString(v)
+		a.Elements = parseArrayString(v)
 	case []byte:
-		return a.scanFromString(string(v))
+		a.Elements = parseArrayString(string(v))
 	case []interface{}:
-		return a.scanFromSlice(v)
-	case []float64:
-		*a = FloatArray(v)
-		return nil
+		elements := make([]string, 0, len(v))
+		for _, item := range v {
+			switch e := item.(type) {
+			case duckdb.Decimal:
+				if a.Precision == 0 {
+					a.Precision, a.Scale = int(e.Width), int(e.Scale)
+				}
+				elements = append(elements, formatDecimal(e))
+			case float64:
+				elements = append(elements, strconv.FormatFloat(e, 'f', -1, 64))
+			default:
+				elements = append(elements, fmt.Sprintf("%v", item))
+			}
+		}
+		a.Elements = elements
 	default:
-		return fmt.Errorf("cannot scan %T into FloatArray", value)
+		return fmt.Errorf("cannot scan %T into DecimalArray", value)
 	}
+	return nil
 }
 
-func (a *FloatArray) scanFromString(s string) error {
-	s = strings.TrimSpace(s)
-
-	if s == "[]" || s == "" {
-		*a = FloatArray{}
-		return nil
+// formatDecimal renders d with exactly its scale in fractional digits; unlike
+// duckdb.Decimal.String it keeps trailing zeros.
+func formatDecimal(d duckdb.Decimal) string {
+	digits := new(big.Int).Abs(d.Value).String()
+	sign := ""
+	if d.Value.Sign() < 0 {
+		sign = "-"
+	}
+	scale := int(d.Scale)
+	if scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
 	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
 
-	// Try JSON unmarshaling first
-	var jsonArray []float64
-	if err := json.Unmarshal([]byte(s), &jsonArray); err == nil {
-		*a = FloatArray(jsonArray)
-		return nil
+// GormDataType implements the GormDataTypeInterface for DecimalArray
+func (a DecimalArray) GormDataType() string {
+	if a.Precision > 0 {
+		return fmt.Sprintf("DECIMAL(%d,%d)[]", a.Precision, a.Scale)
 	}
+	return "DECIMAL[]"
+}
 
-	// Fallback to custom parsing
-	parts := parseArrayString(s)
+// VectorType represents a fixed-size DuckDB FLOAT[n] array such as an
+// embedding. Declare the column length with `gorm:"size:3"` (or
+// `gorm:"type:FLOAT[3]"`); a non-zero Dimension is checked on write.
+type VectorType struct {
+	Elements  []float32 `json:"elements"`
+	Dimension int       `json:"dimension"`
+}
 
-	if len(parts) == 0 {
-		*a = FloatArray{}
-		return nil
+// NewVector creates a VectorType of exactly len(elements) dimensions
+func NewVector(elements ...float32) VectorType {
+	return VectorType{Elements: elements, Dimension: len(elements)}
+}
+
+// Value implements driver.Valuer interface for VectorType
+func (v VectorType) Value() (driver.Value, error) {
+	if v.Elements == nil {
+		return nil, nil
+	}
+	if err := v.checkDimension(); err != nil {
+		return nil, err
 	}
 
-	result := make(FloatArray, 0, len(parts))
-	for _, part := range parts {
-		var f float64
-		if _, err := fmt.Sscanf(part, "%g", &f); err != nil {
-			return fmt.Errorf("cannot parse '%s' as float: %w", part, err)
-		}
-		result = append(result, f)
+	elements := make([]string, len(v.Elements))
+	for i, e := range v.Elements {
+		elements[i] = strconv.FormatFloat(float64(e), 'g', -1, 32)
 	}
+	return "[" + strings.Join(elements, ", ") + "]", nil
+}
 
-	*a = result
-	return nil
+// GormValue binds every element and casts the list to the declared array
+// type, so the column receives FLOAT values of exactly Dimension entries.
+func (v VectorType) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if v.Elements == nil {
+		return clause.Expr{SQL: "NULL"}
+	}
+	if err := v.checkDimension(); err != nil {
+		_ = db.AddError(err)
+		return clause.Expr{SQL: "NULL"}
+	}
+
+	placeholders := make([]string, len(v.Elements))
+	vars := make([]interface{}, len(v.Elements))
+	for i, e := range v.Elements {
+		placeholders[i] = "?"
+		vars[i] = e
+	}
+	return clause.Expr{
+		SQL:  fmt.Sprintf("[%s]::FLOAT[%d]", strings.Join(placeholders, ", "), len(v.Elements)),
+		Vars: vars,
+	}
 }
 
-func (a *FloatArray) scanFromSlice(slice []interface{}) error {
-	result := make(FloatArray, 0, len(slice))
-	for _, item := range slice {
-		switch v := item.(type) {
-		case float64:
-			result = append(result, v)
-		case float32:
-			result = append(result, float64(v))
-		case int64:
-			result = append(result, float64(v))
-		case int:
-			result = append(result, float64(v))
-		default:
-			var f float64
-			if _, err := fmt.Sscanf(fmt.Sprintf("%v", item), "%g", &f); err != nil {
-				return fmt.Errorf("cannot convert %T to float64: %w", item, err)
-			}
-			result = append(result, f)
-		}
+func (v VectorType) checkDimension() error {
+	if v.Dimension > 0 && len(v.Elements) != v.Dimension {
+		return fmt.Errorf("vector has %d elements, expected %d", len(v.Elements), v.Dimension)
+	}
+	if len(v.Elements) == 0 {
+		return fmt.Errorf("vector must have at least one element")
 	}
-	*a = result
 	return nil
 }
 
-// GormDataType implements the GormDataTypeInterface for StringArray
-func (StringArray) GormDataType() string {
-	return "VARCHAR[]"
-}
+// Scan implements sql.Scanner interface for VectorType
+func (v *VectorType) Scan(value interface{}) error {
+	if value == nil {
+		v.Elements = nil
+		return nil
+	}
 
-// GormDataType implements the GormDataTypeInterface for IntArray
-func (IntArray) GormDataType() string {
-	return "BIGINT[]"
+	var floats FloatArray
+	if err := floats.Scan(value); err != nil {
+		return fmt.Errorf("cannot scan %T into VectorType: %w", value, err)
+	}
+	v.Elements = make([]float32, len(floats))
+	for i, f := range floats {
+		v.Elements[i] = float32(f)
+	}
+	v.Dimension = len(v.Elements)
+	return nil
 }
 
-// GormDataType implements the GormDataTypeInterface for FloatArray
-func (FloatArray) GormDataType() string {
-	return "DOUBLE[]"
+// GormDataType implements the GormDataTypeInterface for VectorType
+func (v VectorType) GormDataType() string {
+	if v.Dimension > 0 {
+		return fmt.Sprintf("FLOAT[%d]", v.Dimension)
+	}
+	return "FLOAT[]"
 }
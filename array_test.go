@@ -1,7 +1,11 @@
 package duckdb_test
 
 import (
+	"cmp"
 	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,6 +21,7 @@ type TestArrayModel struct {
 	StringArr duckdb.StringArray `json:"string_arr"`
 	FloatArr  duckdb.FloatArray  `json:"float_arr"`
 	IntArr    duckdb.IntArray    `json:"int_arr"`
+	BoolArr   duckdb.BoolArray   `json:"bool_arr"`
 }
 
 func setupArrayTestDB(t *testing.T) *gorm.DB {
@@ -43,17 +48,17 @@ func TestStringArray_Value(t *testing.T) {
 		{
 			name:     "single element",
 			input:    duckdb.StringArray{"hello"},
-			expected: `["hello"]`,
+			expected: `['hello']`,
 		},
 		{
 			name:     "multiple elements",
 			input:    duckdb.StringArray{"hello", "world", "test"},
-			expected: `["hello","world","test"]`,
+			expected: `['hello', 'world', 'test']`,
 		},
 		{
 			name:     "elements with special characters",
-			input:    duckdb.StringArray{"hello\"world", "test,comma", "newline\n"},
-			expected: `["hello\"world","test,comma","newline\n"]`,
+			input:    duckdb.StringArray{"hello\"world", "test,comma", "newline\n", "it's", `back\slash`},
+			expected: "['hello\"world', 'test,comma', 'newline\n', 'it\\'s', 'back\\\\slash']",
 		},
 	}
 
@@ -127,8 +132,13 @@ func TestStringArray_Scan(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "non-string array element",
-			input:   `[123, "hello"]`,
+			name:     "native list text",
+			input:    `[hello, 'x,y', 'it\'s', ' padded ', NULL, 123]`,
+			expected: duckdb.StringArray{"hello", "x,y", "it's", " padded ", "", "123"},
+		},
+		{
+			name:    "unterminated quote",
+			input:   `['hello, world]`,
 			wantErr: true,
 		},
 	}
@@ -168,12 +178,12 @@ func TestFloatArray_Value(t *testing.T) {
 		{
 			name:     "multiple elements",
 			input:    duckdb.FloatArray{1.1, 2.2, 3.3},
-			expected: "[1.1,2.2,3.3]",
+			expected: "[1.1, 2.2, 3.3]",
 		},
 		{
 			name:     "with zero and negative",
 			input:    duckdb.FloatArray{0.0, -1.5, 2.7},
-			expected: "[0,-1.5,2.7]",
+			expected: "[0, -1.5, 2.7]",
 		},
 	}
 
@@ -282,12 +292,12 @@ func TestIntArray_Value(t *testing.T) {
 		{
 			name:     "multiple elements",
 			input:    duckdb.IntArray{1, 2, 3},
-			expected: "[1,2,3]",
+			expected: "[1, 2, 3]",
 		},
 		{
 			name:     "with zero and negative",
 			input:    duckdb.IntArray{0, -5, 10},
-			expected: "[0,-5,10]",
+			expected: "[0, -5, 10]",
 		},
 	}
 
@@ -377,6 +387,113 @@ func TestIntArray_Scan(t *testing.T) {
 	}
 }
 
+func TestBoolArray_Value(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    duckdb.BoolArray
+		expected string
+	}{
+		{
+			name:     "nil array",
+			input:    nil,
+			expected: "[]",
+		},
+		{
+			name:     "empty array",
+			input:    duckdb.BoolArray{},
+			expected: "[]",
+		},
+		{
+			name:     "multiple elements",
+			input:    duckdb.BoolArray{true, false, true},
+			expected: "[true, false, true]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.input.Value()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestBoolArray_Scan(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected duckdb.BoolArray
+		wantErr  bool
+	}{
+		{
+			name:     "nil input",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "empty array string",
+			input:    "[]",
+			expected: duckdb.BoolArray{},
+		},
+		{
+			name:     "JSON array",
+			input:    "[true,false]",
+			expected: duckdb.BoolArray{true, false},
+		},
+		{
+			name:     "native list text",
+			input:    "[true, false, TRUE]",
+			expected: duckdb.BoolArray{true, false, true},
+		},
+		{
+			name:     "byte slice input",
+			input:    []byte("[false]"),
+			expected: duckdb.BoolArray{false},
+		},
+		{
+			name:     "bool slice input",
+			input:    []bool{true, true},
+			expected: duckdb.BoolArray{true, true},
+		},
+		{
+			name:     "interface slice input",
+			input:    []interface{}{false, true},
+			expected: duckdb.BoolArray{false, true},
+		},
+		{
+			name:    "number element",
+			input:   "[1, 0]",
+			wantErr: true,
+		},
+		{
+			name:    "NULL element",
+			input:   "[true, NULL]",
+			wantErr: true,
+		},
+		{
+			name:    "nil interface element",
+			input:   []interface{}{true, nil},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var arr duckdb.BoolArray
+			err := arr.Scan(tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, arr)
+		})
+	}
+}
+
 func TestMinimalArray_Value(t *testing.T) {
 	// MinimalArray is not implemented - skipping these tests
 	t.Skip("MinimalArray not implemented")
@@ -408,6 +525,11 @@ func TestArrays_GormDataType(t *testing.T) {
 			array:    &duckdb.IntArray{},
 			expected: "BIGINT[]",
 		},
+		{
+			name:     "BoolArray",
+			array:    &duckdb.BoolArray{},
+			expected: "BOOLEAN[]",
+		},
 	}
 
 	for _, tt := range tests {
@@ -426,6 +548,7 @@ func TestArrays_DatabaseIntegration(t *testing.T) {
 		StringArr: duckdb.StringArray{"software", "analytics", "business"},
 		FloatArr:  duckdb.FloatArray{4.5, 4.8, 4.2, 4.9},
 		IntArr:    duckdb.IntArray{1250, 890, 2340, 567},
+		BoolArr:   duckdb.BoolArray{true, false, true},
 	}
 
 	// Create record
@@ -442,11 +565,13 @@ func TestArrays_DatabaseIntegration(t *testing.T) {
 	assert.Equal(t, model.StringArr, retrieved.StringArr)
 	assert.Equal(t, model.FloatArr, retrieved.FloatArr)
 	assert.Equal(t, model.IntArr, retrieved.IntArr)
+	assert.Equal(t, model.BoolArr, retrieved.BoolArr)
 
 	// Test update
 	retrieved.StringArr = append(retrieved.StringArr, "premium")
 	retrieved.FloatArr = append(retrieved.FloatArr, 5.0)
 	retrieved.IntArr = append(retrieved.IntArr, 1000)
+	retrieved.BoolArr = append(retrieved.BoolArr, false)
 
 	err = db.Save(&retrieved).Error
 	require.NoError(t, err)
@@ -462,6 +587,7 @@ func TestArrays_DatabaseIntegration(t *testing.T) {
 	assert.Equal(t, 5.0, updated.FloatArr[4])
 	assert.Equal(t, 5, len(updated.IntArr))
 	assert.Equal(t, int64(1000), updated.IntArr[4])
+	assert.Equal(t, duckdb.BoolArray{true, false, true, false}, updated.BoolArr)
 }
 
 func TestArrays_EmptyAndNilHandling(t *testing.T) {
@@ -472,6 +598,7 @@ func TestArrays_EmptyAndNilHandling(t *testing.T) {
 		StringArr: duckdb.StringArray{},
 		FloatArr:  duckdb.FloatArray{},
 		IntArr:    duckdb.IntArray{},
+		BoolArr:   duckdb.BoolArray{},
 	}
 
 	err := db.Create(&model).Error
@@ -484,12 +611,14 @@ func TestArrays_EmptyAndNilHandling(t *testing.T) {
 	assert.Equal(t, 0, len(retrieved.StringArr))
 	assert.Equal(t, 0, len(retrieved.FloatArr))
 	assert.Equal(t, 0, len(retrieved.IntArr))
+	assert.Equal(t, 0, len(retrieved.BoolArr))
 
 	// Test with nil arrays
 	model2 := TestArrayModel{
 		StringArr: nil,
 		FloatArr:  nil,
 		IntArr:    nil,
+		BoolArr:   nil,
 	}
 
 	err = db.Create(&model2).Error
@@ -504,6 +633,7 @@ func TestArrays_EmptyAndNilHandling(t *testing.T) {
 	assert.Empty(t, retrieved2.StringArr)
 	assert.Empty(t, retrieved2.FloatArr)
 	assert.Empty(t, retrieved2.IntArr)
+	assert.Empty(t, retrieved2.BoolArr)
 }
 
 func TestArrays_ErrorCases(t *testing.T) {
@@ -546,6 +676,22 @@ func TestArrays_ErrorCases(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "cannot convert")
 	})
+
+	t.Run("BoolArray invalid scan types", func(t *testing.T) {
+		var arr duckdb.BoolArray
+
+		// Test unsupported type
+		err := arr.Scan(1)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot scan")
+
+		// Test non-boolean elements
+		err = arr.Scan("[true, maybe]")
+		assert.Error(t, err)
+		err = arr.Scan([]interface{}{true, "yes"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot convert")
+	})
 }
 
 func TestArrays_DriverValueInterface(t *testing.T) {
@@ -553,9 +699,373 @@ func TestArrays_DriverValueInterface(t *testing.T) {
 	var _ driver.Valuer = (*duckdb.StringArray)(nil)
 	var _ driver.Valuer = (*duckdb.FloatArray)(nil)
 	var _ driver.Valuer = (*duckdb.IntArray)(nil)
+	var _ driver.Valuer = (*duckdb.BoolArray)(nil)
 
 	// Test that arrays implement sql.Scanner interface
 	var _ interface{ Scan(interface{}) error } = (*duckdb.StringArray)(nil)
 	var _ interface{ Scan(interface{}) error } = (*duckdb.FloatArray)(nil)
 	var _ interface{ Scan(interface{}) error } = (*duckdb.IntArray)(nil)
+	var _ interface{ Scan(interface{}) error } = (*duckdb.BoolArray)(nil)
+}
+
+func TestEnumArray_DatabaseRoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+
+	type Survey struct {
+		ID    uint             `gorm:"primaryKey"`
+		Moods duckdb.EnumArray `gorm:"type:mood[]"`
+	}
+
+	moods := []string{"happy", "sad", "it's ok"}
+	require.NoError(t, db.Exec("CREATE TYPE mood AS ENUM ('happy', 'sad', 'it''s ok')").Error)
+	require.NoError(t, db.AutoMigrate(&Survey{}))
+
+	survey := Survey{Moods: duckdb.NewEnumArray("mood", moods, "happy", "it's ok")}
+	require.NoError(t, db.Create(&survey).Error)
+
+	var found Survey
+	require.NoError(t, db.First(&found, survey.ID).Error)
+	assert.Equal(t, []string{"happy", "it's ok"}, found.Moods.Selected)
+
+	// Elements outside the allowed set are rejected before reaching DuckDB
+	invalid := Survey{Moods: duckdb.NewEnumArray("mood", moods, "happy", "angry")}
+	err := db.Create(&invalid).Error
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid enum value")
+	assert.False(t, invalid.Moods.IsValid())
+}
+
+func TestEnumArray_GormDataType(t *testing.T) {
+	assert.Equal(t, "mood[]", duckdb.NewEnumArray("mood", nil).GormDataType())
+	assert.Equal(t, "VARCHAR[]", duckdb.EnumArray{}.GormDataType())
+}
+
+func TestDecimalArray_DatabaseRoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+
+	type Ledger struct {
+		ID      uint                `gorm:"primaryKey"`
+		Amounts duckdb.DecimalArray `gorm:"precision:18;scale:2"`
+		Rates   duckdb.DecimalArray `gorm:"type:DECIMAL(10,4)[]"`
+	}
+	require.NoError(t, db.AutoMigrate(&Ledger{}))
+
+	var columnType string
+	require.NoError(t, db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'ledgers' AND column_name = 'amounts'").Scan(&columnType).Error)
+	assert.Equal(t, "DECIMAL(18,2)[]", columnType)
+
+	ledger := Ledger{
+		Amounts: duckdb.NewDecimalArray(18, 2, "1.10", "2.00", "-3.05", "0.07", "12345678901234.99"),
+		Rates:   duckdb.NewDecimalArray(10, 4, "0.5000", "-0.0001"),
+	}
+	require.NoError(t, db.Create(&ledger).Error)
+
+	var found Ledger
+	require.NoError(t, db.First(&found, ledger.ID).Error)
+	assert.Equal(t, []string{"1.10", "2.00", "-3.05", "0.07", "12345678901234.99"}, found.Amounts.Elements)
+	assert.Equal(t, []string{"0.5000", "-0.0001"}, found.Rates.Elements)
+	assert.Equal(t, 18, found.Amounts.Precision)
+	assert.Equal(t, 2, found.Amounts.Scale)
+
+	// The text form keeps trailing zeros as well
+	var text duckdb.DecimalArray
+	require.NoError(t, db.Raw("SELECT amounts::VARCHAR FROM ledgers").Row().Scan(&text))
+	assert.Equal(t, found.Amounts.Elements, text.Elements)
+
+	_, err := duckdb.NewDecimalArray(18, 2, "1.0; DROP TABLE ledgers").Value()
+	assert.Error(t, err)
+}
+
+func TestDecimalArray_GormDataType(t *testing.T) {
+	assert.Equal(t, "DECIMAL(18,2)[]", duckdb.NewDecimalArray(18, 2).GormDataType())
+	assert.Equal(t, "DECIMAL[]", duckdb.DecimalArray{}.GormDataType())
+}
+
+func TestVectorType_DatabaseRoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+
+	type Embedding struct {
+		ID     uint              `gorm:"primaryKey"`
+		Vector duckdb.VectorType `gorm:"size:3"`
+	}
+	require.NoError(t, db.AutoMigrate(&Embedding{}))
+
+	var columnType string
+	require.NoError(t, db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'embeddings' AND column_name = 'vector'").Scan(&columnType).Error)
+	assert.Equal(t, "FLOAT[3]", columnType)
+
+	embedding := Embedding{Vector: duckdb.NewVector(0.25, -1.5, 3.1415927)}
+	require.NoError(t, db.Create(&embedding).Error)
+
+	var found Embedding
+	require.NoError(t, db.First(&found, embedding.ID).Error)
+	assert.Equal(t, []float32{0.25, -1.5, 3.1415927}, found.Vector.Elements)
+	assert.Equal(t, 3, found.Vector.Dimension)
+
+	var distance float64
+	require.NoError(t, db.Raw("SELECT array_distance(vector, ?) FROM embeddings", duckdb.NewVector(0.25, -1.5, 3.1415927)).Scan(&distance).Error)
+	assert.Zero(t, distance)
+
+	short := Embedding{Vector: duckdb.VectorType{Elements: []float32{1, 2}, Dimension: 3}}
+	err := db.Create(&short).Error
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vector has 2 elements, expected 3")
+
+	var count int64
+	require.NoError(t, db.Model(&Embedding{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestVectorType_GormDataType(t *testing.T) {
+	assert.Equal(t, "FLOAT[3]", duckdb.NewVector(1, 2, 3).GormDataType())
+	assert.Equal(t, "FLOAT[]", duckdb.VectorType{}.GormDataType())
+}
+
+func TestArrays_NativeLiteralRoundTrip(t *testing.T) {
+	db := setupArrayTestDB(t)
+
+	strs := duckdb.StringArray{"plain", "it's", "x,y", `back\slash`, ` padded `, "[bracketed]", `say "hi"`, ""}
+	model := TestArrayModel{
+		StringArr: strs,
+		FloatArr:  duckdb.FloatArray{1.5, -0.25, 1e-7},
+		IntArr:    duckdb.IntArray{-3, 0, 9007199254740993},
+	}
+	require.NoError(t, db.Create(&model).Error)
+
+	var found TestArrayModel
+	require.NoError(t, db.First(&found, model.ID).Error)
+	assert.Equal(t, model.StringArr, found.StringArr)
+	assert.Equal(t, model.FloatArr, found.FloatArr)
+	assert.Equal(t, model.IntArr, found.IntArr)
+
+	// Bound values compare equal to the stored lists
+	var count int64
+	require.NoError(t, db.Model(&TestArrayModel{}).
+		Where("string_arr = ? AND float_arr = ? AND int_arr = ?", strs, model.FloatArr, model.IntArr).
+		Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	// DuckDB's own text form scans back to the same values
+	var text duckdb.StringArray
+	require.NoError(t, db.Raw("SELECT string_arr::VARCHAR FROM test_array_models").Row().Scan(&text))
+	assert.Equal(t, strs, text)
+	var ints duckdb.IntArray
+	require.NoError(t, db.Raw("SELECT int_arr::VARCHAR FROM test_array_models").Row().Scan(&ints))
+	assert.Equal(t, model.IntArr, ints)
+	var floats duckdb.FloatArray
+	require.NoError(t, db.Raw("SELECT float_arr::VARCHAR FROM test_array_models").Row().Scan(&floats))
+	assert.Equal(t, model.FloatArr, floats)
+}
+
+// arrayCount is an Array element type outside the built-in ones
+type arrayCount int32
+
+func (arrayCount) ArrayElement() duckdb.ArrayElement[arrayCount] {
+	return duckdb.ArrayElement[arrayCount]{
+		SQLType: "INTEGER",
+		FromDriver: func(value interface{}) (arrayCount, error) {
+			if v, ok := value.(int32); ok {
+				return arrayCount(v), nil
+			}
+			return 0, fmt.Errorf("not an int32")
+		},
+		Parse: func(text string, null bool) (arrayCount, error) {
+			n, err := strconv.ParseInt(text, 10, 32)
+			return arrayCount(n), err
+		},
+		Compare: cmp.Compare[arrayCount],
+	}
+}
+
+func TestArray_CustomElement(t *testing.T) {
+	db := setupTestDB(t)
+	type Sample struct {
+		ID     uint `gorm:"primaryKey"`
+		Counts duckdb.Array[arrayCount]
+	}
+	require.NoError(t, db.AutoMigrate(&Sample{}))
+
+	var columnType string
+	require.NoError(t, db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'samples' AND column_name = 'counts'").Scan(&columnType).Error)
+	assert.Equal(t, "INTEGER[]", columnType)
+
+	sample := Sample{Counts: duckdb.Array[arrayCount]{3, 1, 3, 2}}
+	require.NoError(t, db.Create(&sample).Error)
+
+	var found Sample
+	require.NoError(t, db.First(&found, sample.ID).Error)
+	assert.Equal(t, sample.Counts, found.Counts)
+	assert.Equal(t, duckdb.Array[arrayCount]{1, 2, 3, 3}, found.Counts.Sort())
+	assert.Equal(t, duckdb.Array[arrayCount]{3, 1, 2}, found.Counts.Dedup())
+
+	var text duckdb.Array[arrayCount]
+	require.NoError(t, db.Raw("SELECT counts::VARCHAR FROM samples").Row().Scan(&text))
+	assert.Equal(t, sample.Counts, text)
+
+	// Other element types have no conversion and no column type
+	var unsupported duckdb.Array[complex64]
+	assert.Error(t, unsupported.Scan("[1]"))
+	assert.Panics(t, func() { _ = unsupported.GormDataType() })
+}
+
+func TestMatrix_ValueAndScan(t *testing.T) {
+	value, err := duckdb.IntMatrix{{1, 2}, {3, 4}}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "[[1, 2], [3, 4]]", value)
+
+	value, err = duckdb.IntMatrix{}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "[]", value)
+
+	value, err = duckdb.FloatMatrix{{}, {}}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "[[], []]", value)
+
+	_, err = duckdb.IntMatrix{{1, 2}, {3}}.Value()
+	assert.ErrorContains(t, err, "ragged matrix: row 1 has 1 elements, row 0 has 2")
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected duckdb.IntMatrix
+		wantErr  string
+	}{
+		{name: "nil", input: nil, expected: nil},
+		{name: "empty outer", input: "[]", expected: duckdb.IntMatrix{}},
+		{name: "empty inner", input: "[[], []]", expected: duckdb.IntMatrix{{}, {}}},
+		{name: "JSON", input: "[[1,2],[3,4]]", expected: duckdb.IntMatrix{{1, 2}, {3, 4}}},
+		{name: "native text", input: []byte("[[1, 2], [3, 4]]"), expected: duckdb.IntMatrix{{1, 2}, {3, 4}}},
+		{name: "driver lists", input: []interface{}{[]interface{}{int64(5)}, []interface{}{int64(6)}}, expected: duckdb.IntMatrix{{5}, {6}}},
+		{name: "typed rows", input: [][]int64{{7, 8}}, expected: duckdb.IntMatrix{{7, 8}}},
+		{name: "ragged", input: "[[1, 2], [3]]", wantErr: "ragged matrix"},
+		{name: "ragged typed rows", input: [][]int64{{1}, {}}, wantErr: "ragged matrix"},
+		{name: "NULL row", input: "[[1], NULL]", wantErr: "matrix row 1 is NULL"},
+		{name: "bad element", input: "[[1, x]]", wantErr: "matrix row 0"},
+		{name: "unsupported type", input: 42, wantErr: "cannot scan"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m duckdb.IntMatrix
+			err := m.Scan(tt.input)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, m)
+		})
+	}
+
+	assert.Equal(t, "BIGINT[][]", duckdb.IntMatrix{}.GormDataType())
+	assert.Equal(t, "DOUBLE[][]", duckdb.FloatMatrix{}.GormDataType())
+}
+
+func TestMatrix_DatabaseRoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+
+	type Grid struct {
+		ID      uint `gorm:"primaryKey"`
+		Cells   duckdb.IntMatrix
+		Weights duckdb.FloatMatrix
+	}
+	require.NoError(t, db.AutoMigrate(&Grid{}))
+
+	var columnType string
+	require.NoError(t, db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'grids' AND column_name = 'cells'").Scan(&columnType).Error)
+	assert.Equal(t, "BIGINT[][]", columnType)
+
+	grids := []Grid{
+		{Cells: duckdb.IntMatrix{{1, 2, 3}, {4, 5, 6}}, Weights: duckdb.FloatMatrix{{0.5, -1.25}, {1e-3, 2}}},
+		{Cells: duckdb.IntMatrix{}, Weights: duckdb.FloatMatrix{{}, {}}},
+	}
+	for i := range grids {
+		require.NoError(t, db.Create(&grids[i]).Error)
+	}
+
+	for _, grid := range grids {
+		var found Grid
+		require.NoError(t, db.First(&found, grid.ID).Error)
+		assert.Equal(t, grid.Cells, found.Cells)
+		assert.Equal(t, grid.Weights, found.Weights)
+
+		var text duckdb.FloatMatrix
+		require.NoError(t, db.Raw("SELECT weights::VARCHAR FROM grids WHERE id = ?", grid.ID).Row().Scan(&text))
+		assert.Equal(t, grid.Weights, text)
+	}
+
+	var count int64
+	require.NoError(t, db.Model(&Grid{}).Where("cells = ?", grids[0].Cells).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	// Ragged lists stored by other writers are rejected on read
+	require.NoError(t, db.Exec("INSERT INTO grids (id, cells, weights) VALUES (100, [[1], [2, 3]], [])").Error)
+	var ragged Grid
+	assert.ErrorContains(t, db.First(&ragged, 100).Error, "ragged matrix")
+}
+
+func TestArrays_Sort(t *testing.T) {
+	tags := duckdb.StringArray{"pear", "Apple", "banana", "apple", "banana"}
+	assert.Equal(t, duckdb.StringArray{"Apple", "apple", "banana", "banana", "pear"}, tags.Sort())
+	assert.Equal(t, duckdb.StringArray{"pear", "banana", "banana", "apple", "Apple"}, tags.SortDesc())
+	assert.Equal(t, duckdb.StringArray{"pear", "Apple", "banana", "apple", "banana"}, tags, "receiver is not modified")
+
+	ints := duckdb.IntArray{3, -1, 2, 3, 0}
+	assert.Equal(t, duckdb.IntArray{-1, 0, 2, 3, 3}, ints.Sort())
+	assert.Equal(t, duckdb.IntArray{3, 3, 2, 0, -1}, ints.SortDesc())
+	assert.Equal(t, duckdb.IntArray{3, -1, 2, 3, 0}, ints)
+
+	floats := duckdb.FloatArray{2.5, math.NaN(), -1, 2.5, 0}
+	sorted := floats.Sort()
+	assert.True(t, math.IsNaN(sorted[0]))
+	assert.Equal(t, duckdb.FloatArray{-1, 0, 2.5, 2.5}, sorted[1:])
+	desc := floats.SortDesc()
+	assert.Equal(t, duckdb.FloatArray{2.5, 2.5, 0, -1}, desc[:4])
+	assert.True(t, math.IsNaN(desc[4]))
+
+	// Sorting is idempotent, so sorted arrays compare equal regardless of input order
+	assert.Equal(t, duckdb.IntArray{2, 1, 3}.Sort(), duckdb.IntArray{3, 2, 1}.Sort().Sort())
+
+	// Empty arrays stay empty and nil stays nil
+	assert.Equal(t, duckdb.StringArray{}, duckdb.StringArray{}.Sort())
+	assert.Nil(t, duckdb.IntArray(nil).SortDesc())
+	assert.Nil(t, duckdb.FloatArray(nil).Sort())
+}
+
+func TestArrays_Dedup(t *testing.T) {
+	tags := duckdb.StringArray{"go", "db", "go", "sql", "db"}
+	assert.Equal(t, duckdb.StringArray{"go", "db", "sql"}, tags.Dedup())
+	assert.Equal(t, duckdb.StringArray{"go", "db", "go", "sql", "db"}, tags, "receiver is not modified")
+	assert.Equal(t, duckdb.IntArray{3, 1, 2}, duckdb.IntArray{3, 1, 3, 2, 1}.Dedup())
+
+	floats := duckdb.FloatArray{1.5, math.NaN(), 1.5, math.NaN(), 0}.Dedup()
+	require.Len(t, floats, 3)
+	assert.True(t, math.IsNaN(floats[1]))
+	assert.Nil(t, duckdb.StringArray(nil).Dedup())
+
+	value, err := duckdb.DistinctStringArray{"b", "a", "b"}.Value()
+	require.NoError(t, err)
+	expected, err := duckdb.StringArray{"b", "a"}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, expected, value)
+
+	value, err = duckdb.DistinctIntArray{5, 5, 4}.Value()
+	require.NoError(t, err)
+	expected, err = duckdb.IntArray{5, 4}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, expected, value)
+
+	db := setupArrayTestDB(t)
+	type TaggedPost struct {
+		ID     uint `gorm:"primaryKey"`
+		Tags   duckdb.DistinctStringArray
+		Scores duckdb.DistinctFloatArray
+	}
+	require.NoError(t, db.AutoMigrate(&TaggedPost{}))
+	post := TaggedPost{Tags: duckdb.DistinctStringArray{"x", "y", "x"}, Scores: duckdb.DistinctFloatArray{0.5, 0.5}}
+	require.NoError(t, db.Create(&post).Error)
+
+	var found TaggedPost
+	require.NoError(t, db.First(&found, post.ID).Error)
+	assert.Equal(t, duckdb.DistinctStringArray{"x", "y"}, found.Tags)
+	assert.Equal(t, duckdb.DistinctFloatArray{0.5}, found.Scores)
 }
@@ -0,0 +1,170 @@
+package duckdb
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// describedColumn is one row of DESCRIBE output
+type describedColumn struct {
+	ColumnName string
+	ColumnType string
+}
+
+var (
+	decimalTypePattern = regexp.MustCompile(`^DECIMAL\(\d+,\s*\d+\)$`)
+	listSuffixPattern  = regexp.MustCompile(`\[\d*\]$`)
+)
+
+// goFieldInitialisms are kept upper-case when deriving Go field names.
+var goFieldInitialisms = map[string]bool{
+	"API": true, "HTTP": true, "ID": true, "IP": true, "JSON": true, "SQL": true, "URL": true, "UUID": true,
+}
+
+// GenerateStruct describes query with DESCRIBE and returns the Go source of a
+// struct named typeName whose fields match the result columns, tagged with
+// gorm and db column names. The source is gofmt-formatted and refers to the
+// time package and to this package as duckdb (e.g. duckdb.StringArray);
+// columns of types without a Go mapping become interface{}.
+func GenerateStruct(db *gorm.DB, query string, typeName string) (string, error) {
+	if !token.IsIdentifier(typeName) {
+		return "", fmt.Errorf("invalid type name %q", typeName)
+	}
+	query = strings.TrimRight(strings.TrimSpace(query), ";")
+
+	var columns []describedColumn
+	if err := db.Raw("DESCRIBE " + query).Scan(&columns).Error; err != nil {
+		return "", fmt.Errorf("failed to describe query: %w", err)
+	}
+	if len(columns) == 0 {
+		return "", fmt.Errorf("query has no result columns")
+	}
+
+	var src strings.Builder
+	fmt.Fprintf(&src, "type %s struct {\n", typeName)
+	used := map[string]int{}
+	for _, column := range columns {
+		name := goFieldName(column.ColumnName)
+		if used[name]++; used[name] > 1 {
+			name = fmt.Sprintf("%s%d", name, used[name])
+		}
+		tag := strings.ReplaceAll(column.ColumnName, `"`, `\"`)
+		fmt.Fprintf(&src, "%s %s `gorm:\"column:%s\" db:\"%s\"`\n", name, goTypeOf(column.ColumnType), tag, tag)
+	}
+	src.WriteString("}\n")
+
+	formatted, err := format.Source([]byte(src.String()))
+	if err != nil {
+		return "", fmt.Errorf("failed to format generated struct: %w", err)
+	}
+	return string(formatted), nil
+}
+
+// goTypeOf maps a DuckDB column type as printed by DESCRIBE to a Go type
+func goTypeOf(duckType string) string {
+	t := strings.ToUpper(strings.TrimSpace(duckType))
+
+	if listSuffixPattern.MatchString(t) {
+		element := listSuffixPattern.ReplaceAllString(t, "")
+		switch {
+		case listSuffixPattern.MatchString(element):
+			return "duckdb.ListType"
+		case element == "VARCHAR":
+			return "duckdb.StringArray"
+		case element == "TINYINT", element == "SMALLINT", element == "INTEGER", element == "BIGINT",
+			element == "UTINYINT", element == "USMALLINT", element == "UINTEGER":
+			return "duckdb.IntArray"
+		case element == "FLOAT", element == "DOUBLE":
+			return "duckdb.FloatArray"
+		case element == "BOOLEAN":
+			return "duckdb.BoolArray"
+		case decimalTypePattern.MatchString(element):
+			return "duckdb.DecimalArray"
+		}
+		return "duckdb.ListType"
+	}
+
+	switch {
+	case strings.HasPrefix(t, "STRUCT("):
+		return "duckdb.StructType"
+	case strings.HasPrefix(t, "MAP("):
+		return "duckdb.MapType"
+	case strings.HasPrefix(t, "ENUM("):
+		return "string"
+	case strings.HasPrefix(t, "DECIMAL"):
+		return "duckdb.DecimalType"
+	case strings.HasPrefix(t, "VARCHAR"):
+		return "string"
+	case strings.HasPrefix(t, "TIMESTAMP"), t == "DATE", strings.HasPrefix(t, "TIME"):
+		return "time.Time"
+	}
+
+	switch t {
+	case "BOOLEAN":
+		return "bool"
+	case "TINYINT":
+		return "int8"
+	case "SMALLINT":
+		return "int16"
+	case "INTEGER":
+		return "int32"
+	case "BIGINT":
+		return "int64"
+	case "UTINYINT":
+		return "uint8"
+	case "USMALLINT":
+		return "uint16"
+	case "UINTEGER":
+		return "uint32"
+	case "UBIGINT":
+		return "uint64"
+	case "HUGEINT":
+		return "duckdb.HugeIntType"
+	case "FLOAT":
+		return "float32"
+	case "DOUBLE":
+		return "float64"
+	case "BLOB":
+		return "[]byte"
+	case "INTERVAL":
+		return "duckdb.IntervalType"
+	case "UUID":
+		return "duckdb.UUIDType"
+	case "JSON":
+		return "duckdb.JSONType"
+	}
+	return "interface{}"
+}
+
+// goFieldName turns a column name such as "user_id" into an exported Go
+// identifier such as "UserID"
+func goFieldName(column string) string {
+	words := strings.FieldsFunc(column, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var name strings.Builder
+	for _, word := range words {
+		if upper := strings.ToUpper(word); goFieldInitialisms[upper] {
+			name.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		name.WriteString(string(runes))
+	}
+
+	if name.Len() == 0 {
+		return "Column"
+	}
+	if first := []rune(name.String())[0]; !unicode.IsLetter(first) {
+		return "Column" + name.String()
+	}
+	return name.String()
+}
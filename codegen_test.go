@@ -0,0 +1,50 @@
+package duckdb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+func TestGenerateStruct(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Create(&User{Name: "Ann", Email: "ann@example.com", Age: 31}).Error)
+
+	src, err := duckdb.GenerateStruct(db, `
+		SELECT id AS user_id, name, age::INTEGER AS age, birthday,
+			[age, age + 1] AS ages, ['a', name] AS labels, 1.25::DECIMAL(10,2) AS score,
+			{'city': 'Oslo'} AS address, [age > 30] AS flags, 2.5::DOUBLE AS "2x ratio"
+		FROM users;`, "UserReport")
+	require.NoError(t, err)
+
+	assert.Contains(t, src, "type UserReport struct {")
+	fields := map[string]string{}
+	for _, line := range strings.Split(src, "\n") {
+		if parts := strings.Fields(line); len(parts) >= 3 && strings.HasPrefix(line, "\t") {
+			fields[parts[0]] = parts[1]
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"UserID":        "int32",
+		"Name":          "string",
+		"Age":           "int32",
+		"Birthday":      "time.Time",
+		"Ages":          "duckdb.IntArray",
+		"Labels":        "duckdb.StringArray",
+		"Score":         "duckdb.DecimalType",
+		"Address":       "duckdb.StructType",
+		"Flags":         "duckdb.BoolArray",
+		"Column2xRatio": "float64",
+	}, fields)
+	assert.Contains(t, src, "`gorm:\"column:user_id\" db:\"user_id\"`")
+	assert.Contains(t, src, "`gorm:\"column:2x ratio\" db:\"2x ratio\"`")
+
+	_, err = duckdb.GenerateStruct(db, "SELECT 1", "not a type")
+	assert.Error(t, err)
+	_, err = duckdb.GenerateStruct(db, "SELECT * FROM missing_table", "Missing")
+	assert.Error(t, err)
+}
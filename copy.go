@@ -0,0 +1,636 @@
+package duckdb
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// ErrSchemaMismatch is returned by the import helpers when the file's columns
+// cannot be loaded into the target table.
+var ErrSchemaMismatch = errors.New("file schema does not match table")
+
+// ImportProgress is passed to a ProgressFunc while an import runs.
+type ImportProgress struct {
+	// Percent is 0 when loading starts and 100 once the load has committed.
+	Percent float64
+	// Rows is the number of rows loaded, set on completion.
+	Rows int64
+	// Done is true for the completion report.
+	Done bool
+}
+
+// ProgressFunc receives import progress. go-duckdb does not expose progress
+// for a running statement, so the import helpers report only the start of the
+// load and its completion with the final row count.
+type ProgressFunc func(ImportProgress)
+
+// CSVImportOptions configures ImportCSV
+type CSVImportOptions struct {
+	// Replace empties the table before loading, in the same transaction, so
+	// the import replaces its contents. By default rows are appended.
+	Replace bool
+	// Header reports whether the first line of the file holds column names.
+	Header bool
+	// Delimiter overrides the field separator. DuckDB sniffs it when empty.
+	Delimiter string
+	// IgnoreErrors skips rows that fail to parse instead of aborting the import.
+	IgnoreErrors bool
+	// RejectsTable records skipped rows in this temporary table (and their scans
+	// in RejectsTable+"_scans"); read them back with ReadCSVRejects. Setting it
+	// implies IgnoreErrors. Rejects from repeated imports accumulate.
+	RejectsTable string
+	// NormalizeHeader renames the file's columns with NormalizeColumnName and
+	// loads them into the table columns of the same name, so "First Name" fills
+	// first_name. Table columns missing from the file take their defaults.
+	// Implies Header.
+	NormalizeHeader bool
+	// Credentials authenticate an s3:// path, see S3Credentials.
+	Credentials *S3Credentials
+	// Progress, if set, is told when the load starts and when it completes.
+	Progress ProgressFunc
+}
+
+// CSVReject describes one CSV row skipped during ImportCSV, see RejectsTable
+type CSVReject struct {
+	ScanID       uint64
+	FileID       uint64
+	FilePath     string
+	Line         uint64
+	ColumnName   string
+	ErrorType    string
+	CSVLine      string
+	ErrorMessage string
+}
+
+// ImportCSV loads a CSV file into the table backing model using COPY ... FROM
+// and returns the number of rows the COPY inserted. model may be a struct
+// pointer or a table name. path may be local or remote (s3://, https://).
+func ImportCSV(db *gorm.DB, model interface{}, path string, opts CSVImportOptions) (int64, error) {
+	table, err := resolveModelTable(db, model)
+	if err != nil {
+		return 0, err
+	}
+	if err := prepareRemotePath(db, path, opts.Credentials); err != nil {
+		return 0, err
+	}
+
+	// Options shared by COPY and read_csv, as name/literal pairs
+	header := opts.Header || opts.NormalizeHeader
+	options := [][2]string{{"HEADER", fmt.Sprintf("%t", header)}}
+	if opts.Delimiter != "" {
+		options = append(options, [2]string{"DELIMITER", quoteLiteral(opts.Delimiter)})
+	}
+	sniffOptions := options
+	if opts.RejectsTable != "" {
+		options = append(options, [2]string{"STORE_REJECTS", "true"},
+			[2]string{"REJECTS_TABLE", quoteLiteral(opts.RejectsTable)},
+			[2]string{"REJECTS_SCAN", quoteLiteral(rejectScansTable(opts.RejectsTable))})
+	} else if opts.IgnoreErrors {
+		options = append(options, [2]string{"IGNORE_ERRORS", "true"})
+	}
+
+	var loadSQL string
+	if opts.NormalizeHeader {
+		loadSQL, err = normalizedCSVInsert(db, table, path, sniffOptions, options)
+		if err != nil {
+			return 0, fmt.Errorf("failed to import CSV %s into %s: %w", path, table, err)
+		}
+	} else {
+		copyOptions := []string{"FORMAT CSV"}
+		for _, option := range options {
+			copyOptions = append(copyOptions, option[0]+" "+option[1])
+		}
+		loadSQL = fmt.Sprintf("COPY %s FROM %s (%s)",
+			db.Statement.Quote(table), quoteLiteral(path), strings.Join(copyOptions, ", "))
+	}
+
+	rows, err := loadTable(db, table, loadSQL, opts.Replace, opts.Progress)
+	if err != nil {
+		return 0, fmt.Errorf("failed to import CSV %s into %s: %w", path, table, err)
+	}
+	return rows, nil
+}
+
+// normalizedCSVInsert reads the file's header and builds an INSERT ... BY NAME
+// that selects every CSV column under its NormalizeColumnName alias
+func normalizedCSVInsert(db *gorm.DB, table, path string, sniffOptions, options [][2]string) (string, error) {
+	var headers []describedColumn
+	if err := db.Raw("DESCRIBE SELECT * FROM " + readCSVCall(path, sniffOptions)).Scan(&headers).Error; err != nil {
+		return "", fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make([]string, len(headers))
+	seen := map[string]bool{}
+	for i, header := range headers {
+		name := NormalizeColumnName(header.ColumnName)
+		if name == "" {
+			name = fmt.Sprintf("column%d", i)
+		}
+		if seen[name] {
+			return "", fmt.Errorf("CSV headers normalize to the same column %q", name)
+		}
+		seen[name] = true
+		columns[i] = db.Statement.Quote(header.ColumnName) + " AS " + db.Statement.Quote(name)
+	}
+
+	return fmt.Sprintf("INSERT INTO %s BY NAME SELECT %s FROM %s",
+		db.Statement.Quote(table), strings.Join(columns, ", "), readCSVCall(path, options)), nil
+}
+
+func readCSVCall(path string, options [][2]string) string {
+	args := []string{quoteLiteral(path)}
+	for _, option := range options {
+		args = append(args, strings.ToLower(option[0])+" = "+option[1])
+	}
+	return "read_csv(" + strings.Join(args, ", ") + ")"
+}
+
+// NormalizeColumnName turns a CSV header such as "First Name" or "OrderID"
+// into a snake_case column name ("first_name", "order_id"), the form GORM's
+// default naming strategy gives struct fields.
+func NormalizeColumnName(header string) string {
+	words := strings.FieldsFunc(header, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = schema.NamingStrategy{}.ColumnName("", word)
+	}
+	return strings.Join(words, "_")
+}
+
+// ReadCSVRejects returns the rows recorded in rejectsTable by ImportCSV, in
+// scan and line order.
+func ReadCSVRejects(db *gorm.DB, rejectsTable string) ([]CSVReject, error) {
+	query := fmt.Sprintf(`SELECT r.scan_id, r.file_id, COALESCE(s.file_path, '') AS file_path, r.line,
+			COALESCE(r.column_name, '') AS column_name, CAST(r.error_type AS VARCHAR) AS error_type,
+			r.csv_line, r.error_message
+		FROM %s r LEFT JOIN %s s ON s.scan_id = r.scan_id AND s.file_id = r.file_id
+		ORDER BY r.scan_id, r.line`,
+		db.Statement.Quote(rejectsTable), db.Statement.Quote(rejectScansTable(rejectsTable)))
+
+	var rejects []CSVReject
+	if err := db.Raw(query).Scan(&rejects).Error; err != nil {
+		return nil, fmt.Errorf("failed to read CSV rejects from %s: %w", rejectsTable, err)
+	}
+	return rejects, nil
+}
+
+func rejectScansTable(rejectsTable string) string {
+	return rejectsTable + "_scans"
+}
+
+// ParquetImportOptions configures ImportParquet
+type ParquetImportOptions struct {
+	// Replace empties the table before loading, in the same transaction, so
+	// the import replaces its contents. By default rows are appended.
+	Replace bool
+	// Columns limits the load to these columns, matched by name in the file.
+	// Other table columns take their defaults. Empty loads every column by position.
+	Columns []string
+	// Credentials authenticate an s3:// path, see S3Credentials.
+	Credentials *S3Credentials
+	// Progress, if set, is told when the load starts and when it completes.
+	Progress ProgressFunc
+}
+
+// ImportParquet loads Parquet data into the table backing model and returns the
+// number of rows inserted. path may be a glob (e.g. "data/*.parquet") to load
+// several files at once, and may be remote (s3://, https://). model may be a
+// struct pointer or a table name.
+func ImportParquet(db *gorm.DB, model interface{}, path string, opts ParquetImportOptions) (int64, error) {
+	table, err := resolveModelTable(db, model)
+	if err != nil {
+		return 0, err
+	}
+	if err := prepareRemotePath(db, path, opts.Credentials); err != nil {
+		return 0, err
+	}
+
+	quotedTable := db.Statement.Quote(table)
+	var loadSQL string
+	if len(opts.Columns) > 0 {
+		columns := make([]string, len(opts.Columns))
+		for i, column := range opts.Columns {
+			columns[i] = db.Statement.Quote(column)
+		}
+		columnList := strings.Join(columns, ", ")
+		loadSQL = fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM read_parquet(%s)",
+			quotedTable, columnList, columnList, quoteLiteral(path))
+	} else {
+		loadSQL = fmt.Sprintf("COPY %s FROM %s (FORMAT PARQUET)", quotedTable, quoteLiteral(path))
+	}
+
+	rows, err := loadTable(db, table, loadSQL, opts.Replace, opts.Progress)
+	if err != nil {
+		if isSchemaMismatch(err) {
+			return 0, fmt.Errorf("failed to import Parquet %s into %s: %w: %w", path, table, ErrSchemaMismatch, err)
+		}
+		return 0, fmt.Errorf("failed to import Parquet %s into %s: %w", path, table, err)
+	}
+	return rows, nil
+}
+
+// CopyFormat is a file format understood by COPY
+type CopyFormat string
+
+// File formats for CopyFrom
+const (
+	CopyFormatCSV     CopyFormat = "CSV"
+	CopyFormatParquet CopyFormat = "PARQUET"
+	CopyFormatJSON    CopyFormat = "JSON"
+)
+
+// CopyOptions configures CopyFrom
+type CopyOptions struct {
+	// Format of the file, CSV when empty.
+	Format CopyFormat
+	// Delimiter overrides the CSV field separator. DuckDB sniffs it when empty.
+	Delimiter string
+	// Header reports whether the first line of a CSV file holds column names.
+	Header bool
+	// Columns names the table columns the file's columns load into, in file
+	// order. Other table columns take their defaults. Empty loads every column.
+	Columns []string
+}
+
+// CopyFrom appends the rows of a CSV, Parquet or JSON file to table with
+// COPY ... FROM and returns the number of rows loaded. Unlike ImportCSV and
+// ImportParquet it runs a single statement and has no Replace option.
+func CopyFrom(db *gorm.DB, table string, path string, opts CopyOptions) (int64, error) {
+	if err := validateCopyName("table name", table); err != nil {
+		return 0, err
+	}
+	if err := validateCopyName("path", path); err != nil {
+		return 0, err
+	}
+
+	copyOptions, err := opts.formatOptions()
+	if err != nil {
+		return 0, err
+	}
+
+	target := db.Statement.Quote(table)
+	if len(opts.Columns) > 0 {
+		columns := make([]string, len(opts.Columns))
+		for i, column := range opts.Columns {
+			if err := validateCopyName("column name", column); err != nil {
+				return 0, err
+			}
+			columns[i] = db.Statement.Quote(column)
+		}
+		target += " (" + strings.Join(columns, ", ") + ")"
+	}
+
+	result := db.Exec(fmt.Sprintf("COPY %s FROM %s (%s)", target, quoteLiteral(path), strings.Join(copyOptions, ", ")))
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to copy %s into %s: %w", path, table, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// CopyTo writes the result of query to path with COPY (...) TO. query is built
+// like a Find, with Model or Table and conditions, or with Raw; its bind
+// variables are inlined as literals because COPY cannot take parameters for
+// the inner query. The CSV header is written when opts.Header is set, and
+// opts.Columns limits the export to those result columns.
+func CopyTo(db *gorm.DB, query *gorm.DB, path string, opts CopyOptions) error {
+	if err := validateCopyName("path", path); err != nil {
+		return err
+	}
+	copyOptions, err := opts.formatOptions()
+	if err != nil {
+		return err
+	}
+
+	stmt := query.Statement
+	if stmt.SQL.Len() == 0 {
+		var rows []map[string]interface{}
+		stmt = query.Session(&gorm.Session{DryRun: true}).Find(&rows).Statement
+		if stmt.Error != nil {
+			return fmt.Errorf("failed to build query to export: %w", stmt.Error)
+		}
+	}
+	querySQL, err := inlineVars(stmt.SQL.String(), stmt.Vars)
+	if err != nil {
+		return fmt.Errorf("failed to build query to export: %w", err)
+	}
+
+	if len(opts.Columns) > 0 {
+		columns := make([]string, len(opts.Columns))
+		for i, column := range opts.Columns {
+			if err := validateCopyName("column name", column); err != nil {
+				return err
+			}
+			columns[i] = db.Statement.Quote(column)
+		}
+		querySQL = fmt.Sprintf("SELECT %s FROM (%s)", strings.Join(columns, ", "), querySQL)
+	}
+
+	copySQL := fmt.Sprintf("COPY (%s) TO %s (%s)", querySQL, quoteLiteral(path), strings.Join(copyOptions, ", "))
+	if err := db.Exec(copySQL).Error; err != nil {
+		return fmt.Errorf("failed to copy query results to %s: %w", path, err)
+	}
+	return nil
+}
+
+// formatOptions renders the COPY options for the file format, defaulting to CSV
+func (opts CopyOptions) formatOptions() ([]string, error) {
+	format := CopyFormat(strings.ToUpper(string(opts.Format)))
+	if format == "" {
+		format = CopyFormatCSV
+	}
+	copyOptions := []string{"FORMAT " + string(format)}
+	switch format {
+	case CopyFormatCSV:
+		copyOptions = append(copyOptions, fmt.Sprintf("HEADER %t", opts.Header))
+		if opts.Delimiter != "" {
+			copyOptions = append(copyOptions, "DELIMITER "+quoteLiteral(opts.Delimiter))
+		}
+	case CopyFormatParquet, CopyFormatJSON:
+		if opts.Header || opts.Delimiter != "" {
+			return nil, fmt.Errorf("header and delimiter only apply to CSV, not %s", format)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported COPY format %q", opts.Format)
+	}
+	return copyOptions, nil
+}
+
+// inlineVars replaces the ? placeholders of query, outside quoted strings and
+// identifiers, with vars rendered as SQL literals
+func inlineVars(query string, vars []interface{}) (string, error) {
+	var b strings.Builder
+	next := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			// A doubled quote inside a quoted section closes and reopens it
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '?':
+			if next >= len(vars) {
+				return "", fmt.Errorf("query has more placeholders than its %d vars", len(vars))
+			}
+			literal, err := varLiteral(vars[next])
+			if err != nil {
+				return "", fmt.Errorf("var %d: %w", next, err)
+			}
+			next++
+			b.WriteString(literal)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	if next != len(vars) {
+		return "", fmt.Errorf("query has %d placeholders for %d vars", next, len(vars))
+	}
+	return b.String(), nil
+}
+
+// varLiteral renders a bind variable as a DuckDB literal. Times are written
+// as UTC timestamps, as the driver binds them; scalars go through sqlLiteral.
+func varLiteral(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case time.Time:
+		return sqlLiteral(v.UTC())
+	case []byte:
+		var b strings.Builder
+		for _, c := range v {
+			fmt.Fprintf(&b, "\\x%02X", c)
+		}
+		return quoteLiteral(b.String()) + "::BLOB", nil
+	case driver.Valuer:
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "NULL", nil
+		}
+		inner, err := v.Value()
+		if err != nil {
+			return "", err
+		}
+		return varLiteral(inner)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "NULL", nil
+		}
+		return varLiteral(rv.Elem().Interface())
+	case reflect.String:
+		return quoteLiteral(rv.String()), nil
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return quoteLiteral(strconv.FormatFloat(f, 'g', -1, 64)) + "::DOUBLE", nil
+		}
+	case reflect.Slice:
+		// Lists are written as text and cast where they are compared or stored
+		list, err := formatSliceForDuckDB(value)
+		if err != nil {
+			return "", err
+		}
+		return quoteLiteral(list), nil
+	}
+	return sqlLiteral(value)
+}
+
+// validateCopyName rejects empty names and characters that could end the
+// quoted identifier or literal they are placed in. Single quotes in paths are
+// escaped by quoteLiteral instead.
+func validateCopyName(kind, name string) error {
+	if name == "" {
+		return fmt.Errorf("%s is empty", kind)
+	}
+	invalid := "\x00"
+	if kind != "path" {
+		invalid += `"`
+	}
+	if strings.ContainsAny(name, invalid) {
+		return fmt.Errorf("invalid %s %q", kind, name)
+	}
+	return nil
+}
+
+// S3Credentials authenticate s3:// paths for the import helpers. They are
+// registered as a DuckDB secret scoped to the path's bucket before loading and
+// kept out of GORM's SQL log and returned errors.
+type S3Credentials struct {
+	KeyID        string
+	Secret       string
+	SessionToken string
+	Region       string
+	// Endpoint overrides the S3 host, e.g. for MinIO or R2.
+	Endpoint string
+	// Profile takes the credentials from this AWS profile through the aws
+	// extension's credential chain instead of KeyID and Secret.
+	Profile string
+}
+
+var remotePathPrefixes = []string{"s3://", "gs://", "r2://", "http://", "https://"}
+
+// prepareRemotePath loads httpfs when path is remote and, for s3:// paths with
+// credentials, creates the secret the load will use. Local paths are untouched.
+func prepareRemotePath(db *gorm.DB, path string, creds *S3Credentials) error {
+	remote := false
+	for _, prefix := range remotePathPrefixes {
+		if strings.HasPrefix(strings.ToLower(path), prefix) {
+			remote = true
+			break
+		}
+	}
+	if !remote {
+		if creds != nil {
+			return fmt.Errorf("credentials only apply to remote paths, got %s", path)
+		}
+		return nil
+	}
+
+	extensions := []string{ExtensionHTTPS}
+	if creds != nil && creds.Profile != "" {
+		extensions = append(extensions, ExtensionS3)
+	}
+	for _, extension := range extensions {
+		if err := db.Exec("INSTALL " + extension).Error; err != nil {
+			return fmt.Errorf("failed to install %s for %s: %w", extension, path, err)
+		}
+		if err := db.Exec("LOAD " + extension).Error; err != nil {
+			return fmt.Errorf("failed to load %s for %s: %w", extension, path, err)
+		}
+	}
+	if creds == nil {
+		return nil
+	}
+
+	bucket, ok := strings.CutPrefix(path, "s3://")
+	if !ok {
+		return fmt.Errorf("credentials only apply to s3:// paths, got %s", path)
+	}
+	bucket, _, _ = strings.Cut(bucket, "/")
+
+	options := []string{"TYPE S3"}
+	if creds.Profile != "" {
+		options = append(options, "PROVIDER credential_chain", "PROFILE "+quoteLiteral(creds.Profile))
+	} else {
+		options = append(options, "KEY_ID "+quoteLiteral(creds.KeyID), "SECRET "+quoteLiteral(creds.Secret))
+	}
+	if creds.SessionToken != "" {
+		options = append(options, "SESSION_TOKEN "+quoteLiteral(creds.SessionToken))
+	}
+	if creds.Region != "" {
+		options = append(options, "REGION "+quoteLiteral(creds.Region))
+	}
+	if creds.Endpoint != "" {
+		options = append(options, "ENDPOINT "+quoteLiteral(creds.Endpoint))
+	}
+	options = append(options, "SCOPE "+quoteLiteral("s3://"+bucket))
+
+	name := "gorm_duckdb_s3_" + strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '_'
+	}, bucket)
+	secretSQL := fmt.Sprintf("CREATE OR REPLACE SECRET %s (%s)", name, strings.Join(options, ", "))
+
+	// The statement embeds the secret, so it must not reach the SQL log
+	quiet := db.Session(&gorm.Session{Logger: db.Logger.LogMode(logger.Silent)})
+	if err := quiet.Exec(secretSQL).Error; err != nil {
+		return fmt.Errorf("failed to create S3 secret for %s: %w", path, redactedError{err, []string{creds.Secret, creds.SessionToken}})
+	}
+	return nil
+}
+
+// redactedError masks secrets that DuckDB may echo back in error messages
+type redactedError struct {
+	err     error
+	secrets []string
+}
+
+func (e redactedError) Error() string {
+	msg := e.err.Error()
+	for _, secret := range e.secrets {
+		if secret != "" {
+			msg = strings.ReplaceAll(msg, secret, "***")
+		}
+	}
+	return msg
+}
+
+// loadTable runs loadSQL inside a transaction, first emptying the table when
+// replacing, and returns the row count DuckDB reports for the load. progress
+// may be nil.
+func loadTable(db *gorm.DB, table, loadSQL string, replace bool, progress ProgressFunc) (int64, error) {
+	if progress != nil {
+		progress(ImportProgress{})
+	}
+
+	var rows int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if replace {
+			if err := tx.Exec("DELETE FROM " + tx.Statement.Quote(table)).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Exec(loadSQL)
+		if result.Error != nil {
+			return result.Error
+		}
+		rows = result.RowsAffected
+		return nil
+	})
+	if err == nil && progress != nil {
+		progress(ImportProgress{Percent: 100, Rows: rows, Done: true})
+	}
+	return rows, err
+}
+
+// isSchemaMismatch reports whether a load failed because the file's columns
+// do not line up with the table
+func isSchemaMismatch(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "column count mismatch") ||
+		strings.Contains(msg, "schema does not match") ||
+		strings.Contains(msg, "failed to cast column") ||
+		(strings.Contains(msg, "binder error") && strings.Contains(msg, "not found"))
+}
+
+// resolveModelTable returns the table name for a model, accepting either a
+// table name string or anything GORM can parse into a schema.
+func resolveModelTable(db *gorm.DB, model interface{}) (string, error) {
+	if name, ok := model.(string); ok {
+		if name == "" {
+			return "", fmt.Errorf("table name is empty")
+		}
+		return name, nil
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return "", fmt.Errorf("failed to resolve table for %T: %w", model, err)
+	}
+	return stmt.Schema.Table, nil
+}
+
+// quoteLiteral renders s as a single-quoted SQL string literal
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
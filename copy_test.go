@@ -0,0 +1,376 @@
+package duckdb_test
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+type CopyReading struct {
+	ID     uint `gorm:"primaryKey;autoIncrement:false"`
+	Sensor string
+	Value  float64
+}
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestImportCSV_AppendCounts(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CopyReading{}))
+
+	first := writeTestFile(t, "first.csv", "id,sensor,value\n1,a,1.5\n2,b,2.5\n")
+	second := writeTestFile(t, "second.csv", "id,sensor,value\n3,a,3.5\n4,b,4.5\n5,c,5.5\n")
+
+	n, err := duckdb.ImportCSV(db, &CopyReading{}, first, duckdb.CSVImportOptions{Header: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	// Imports append by default
+	n, err = duckdb.ImportCSV(db, &CopyReading{}, second, duckdb.CSVImportOptions{Header: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	var total int64
+	require.NoError(t, db.Model(&CopyReading{}).Count(&total).Error)
+	assert.Equal(t, int64(5), total)
+
+	// Replace empties the table first
+	n, err = duckdb.ImportCSV(db, "copy_readings", first, duckdb.CSVImportOptions{Header: true, Replace: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	require.NoError(t, db.Model(&CopyReading{}).Count(&total).Error)
+	assert.Equal(t, int64(2), total)
+}
+
+func TestImportCSV_NormalizeHeader(t *testing.T) {
+	db := setupTestDB(t)
+
+	type CSVContact struct {
+		ID        uint `gorm:"primaryKey"`
+		FirstName string
+		ZipCode   string
+		OrderID   int
+	}
+	require.NoError(t, db.AutoMigrate(&CSVContact{}))
+
+	path := writeTestFile(t, "contacts.csv", "First Name,Zip-Code,OrderID\nAda,0150,7\nLin,5003,9\n")
+	n, err := duckdb.ImportCSV(db, &CSVContact{}, path, duckdb.CSVImportOptions{NormalizeHeader: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	var firstNames []string
+	require.NoError(t, db.Model(&CSVContact{}).Order("first_name").Pluck("first_name", &firstNames).Error)
+	assert.Equal(t, []string{"Ada", "Lin"}, firstNames)
+
+	var contact CSVContact
+	require.NoError(t, db.Where("first_name = ?", "Ada").First(&contact).Error)
+	assert.NotZero(t, contact.ID, "columns missing from the file take their defaults")
+	assert.Equal(t, 7, contact.OrderID)
+
+	assert.Equal(t, "first_name", duckdb.NormalizeColumnName("First Name"))
+	assert.Equal(t, "order_id", duckdb.NormalizeColumnName(" OrderID "))
+	assert.Equal(t, "unit_price_eur", duckdb.NormalizeColumnName("Unit Price (EUR)"))
+
+	clash := writeTestFile(t, "clash.csv", "First Name,first_name\na,b\n")
+	_, err = duckdb.ImportCSV(db, &CSVContact{}, clash, duckdb.CSVImportOptions{NormalizeHeader: true})
+	assert.Error(t, err)
+}
+
+func TestImportCSV_Delimiter(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CopyReading{}))
+
+	path := writeTestFile(t, "readings.csv", "1|x|0.25\n")
+	n, err := duckdb.ImportCSV(db, &CopyReading{}, path, duckdb.CSVImportOptions{Delimiter: "|"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	var reading CopyReading
+	require.NoError(t, db.First(&reading, 1).Error)
+	assert.Equal(t, "x", reading.Sensor)
+	assert.InDelta(t, 0.25, reading.Value, 1e-9)
+}
+
+func TestImportCSV_MissingFile(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CopyReading{}))
+	require.NoError(t, db.Create(&CopyReading{ID: 1, Sensor: "keep"}).Error)
+
+	_, err := duckdb.ImportCSV(db, &CopyReading{}, filepath.Join(t.TempDir(), "missing.csv"), duckdb.CSVImportOptions{})
+	require.Error(t, err)
+
+	// The failed import rolls back, leaving existing rows untouched
+	var total int64
+	require.NoError(t, db.Model(&CopyReading{}).Count(&total).Error)
+	assert.Equal(t, int64(1), total)
+}
+
+type CopyReadingArchive struct {
+	ID     uint `gorm:"primaryKey;autoIncrement:false"`
+	Sensor string
+	Value  float64
+}
+
+func TestImportParquet_RoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CopyReading{}, &CopyReadingArchive{}))
+
+	readings := []CopyReading{{ID: 1, Sensor: "a", Value: 1.5}, {ID: 2, Sensor: "b", Value: 2.5}, {ID: 3, Sensor: "c", Value: 3.5}}
+	for i := range readings {
+		require.NoError(t, db.Create(&readings[i]).Error)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "readings.parquet")
+	require.NoError(t, db.Exec("COPY copy_readings TO '"+path+"' (FORMAT PARQUET)").Error)
+
+	n, err := duckdb.ImportParquet(db, &CopyReadingArchive{}, path, duckdb.ParquetImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	var restored []CopyReadingArchive
+	require.NoError(t, db.Order("id").Find(&restored).Error)
+	require.Len(t, restored, 3)
+	for i, r := range readings {
+		assert.Equal(t, CopyReadingArchive(r), restored[i])
+	}
+
+	// Globs load several files in one go
+	second := filepath.Join(dir, "readings_2.parquet")
+	require.NoError(t, db.Exec("COPY (SELECT id + 10 AS id, sensor, value FROM copy_readings) TO '"+second+"' (FORMAT PARQUET)").Error)
+	n, err = duckdb.ImportParquet(db, "copy_reading_archives", filepath.Join(dir, "*.parquet"), duckdb.ParquetImportOptions{Replace: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), n)
+
+	var total int64
+	require.NoError(t, db.Model(&CopyReadingArchive{}).Count(&total).Error)
+	assert.Equal(t, int64(6), total)
+}
+
+func TestImportParquet_ColumnsAndMismatch(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CopyReading{}))
+
+	path := filepath.Join(t.TempDir(), "wide.parquet")
+	require.NoError(t, db.Exec("COPY (SELECT 7 AS id, 'z' AS sensor, 9.5 AS value, 'extra' AS note) TO '"+path+"' (FORMAT PARQUET)").Error)
+
+	// Loading by position fails because the file has an extra column
+	_, err := duckdb.ImportParquet(db, &CopyReading{}, path, duckdb.ParquetImportOptions{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, duckdb.ErrSchemaMismatch)
+
+	// Selecting a column subset loads by name
+	n, err := duckdb.ImportParquet(db, &CopyReading{}, path, duckdb.ParquetImportOptions{Columns: []string{"sensor", "id"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	var reading CopyReading
+	require.NoError(t, db.First(&reading, 7).Error)
+	assert.Equal(t, "z", reading.Sensor)
+	assert.Zero(t, reading.Value)
+
+	// A requested column the file does not have is a mismatch too
+	narrow := filepath.Join(t.TempDir(), "narrow.parquet")
+	require.NoError(t, db.Exec("COPY (SELECT 8 AS id) TO '"+narrow+"' (FORMAT PARQUET)").Error)
+	_, err = duckdb.ImportParquet(db, &CopyReading{}, narrow, duckdb.ParquetImportOptions{Columns: []string{"id", "value"}})
+	assert.ErrorIs(t, err, duckdb.ErrSchemaMismatch)
+}
+
+func TestImportCSV_Rejects(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CopyReading{}))
+
+	path := writeTestFile(t, "dirty.csv", "id,sensor,value\n1,a,1.5\nx,b,2\n3,c,zz\n4,d,4\n")
+
+	// Without error handling the bad rows abort the import
+	_, err := duckdb.ImportCSV(db, &CopyReading{}, path, duckdb.CSVImportOptions{Header: true})
+	require.Error(t, err)
+
+	n, err := duckdb.ImportCSV(db, &CopyReading{}, path, duckdb.CSVImportOptions{Header: true, RejectsTable: "reading_rejects"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	rejects, err := duckdb.ReadCSVRejects(db, "reading_rejects")
+	require.NoError(t, err)
+	require.Len(t, rejects, 2)
+
+	assert.Equal(t, uint64(3), rejects[0].Line)
+	assert.Equal(t, "id", rejects[0].ColumnName)
+	assert.Equal(t, "CAST", rejects[0].ErrorType)
+	assert.Equal(t, "x,b,2", rejects[0].CSVLine)
+	assert.Contains(t, rejects[0].ErrorMessage, `"x"`)
+	assert.Equal(t, path, rejects[0].FilePath)
+
+	assert.Equal(t, uint64(4), rejects[1].Line)
+	assert.Equal(t, "value", rejects[1].ColumnName)
+
+	// IgnoreErrors alone skips bad rows without recording them
+	n, err = duckdb.ImportCSV(db, &CopyReading{}, path, duckdb.CSVImportOptions{Header: true, IgnoreErrors: true, Replace: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+}
+
+func TestImport_Progress(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CopyReading{}))
+
+	path := writeTestFile(t, "progress.csv", "id,sensor,value\n1,a,1.5\n2,b,2.5\n3,c,3.5\n")
+
+	var reports []duckdb.ImportProgress
+	record := func(p duckdb.ImportProgress) { reports = append(reports, p) }
+
+	n, err := duckdb.ImportCSV(db, &CopyReading{}, path, duckdb.CSVImportOptions{Header: true, Progress: record})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	require.NotEmpty(t, reports)
+	assert.Equal(t, duckdb.ImportProgress{}, reports[0])
+	assert.Equal(t, duckdb.ImportProgress{Percent: 100, Rows: 3, Done: true}, reports[len(reports)-1])
+
+	// Parquet imports report the same way
+	parquet := filepath.Join(t.TempDir(), "progress.parquet")
+	require.NoError(t, db.Exec("COPY copy_readings TO '"+parquet+"' (FORMAT PARQUET)").Error)
+	reports = nil
+	_, err = duckdb.ImportParquet(db, &CopyReading{}, parquet, duckdb.ParquetImportOptions{Replace: true, Progress: record})
+	require.NoError(t, err)
+	assert.Equal(t, duckdb.ImportProgress{Percent: 100, Rows: 3, Done: true}, reports[len(reports)-1])
+
+	// A failed load never reports completion
+	reports = nil
+	_, err = duckdb.ImportCSV(db, &CopyReading{}, filepath.Join(t.TempDir(), "missing.csv"), duckdb.CSVImportOptions{Progress: record})
+	require.Error(t, err)
+	for _, p := range reports {
+		assert.False(t, p.Done)
+	}
+}
+
+func TestImport_RemotePathCredentials(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CopyReading{}))
+
+	// Record the statements without running them; httpfs needs network access
+	var statements []string
+	require.NoError(t, db.Callback().Raw().Before("gorm:raw").Register("test:capture_sql", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+	var sqlLog bytes.Buffer
+	dryRun := db.Session(&gorm.Session{
+		DryRun: true,
+		Logger: logger.New(log.New(&sqlLog, "", 0), logger.Config{LogLevel: logger.Info}),
+	})
+
+	creds := &duckdb.S3Credentials{KeyID: "AKIDEXAMPLE", Secret: "s3cr3t-value", Region: "eu-west-1"}
+	_, err := duckdb.ImportParquet(dryRun, &CopyReading{}, "s3://sensor-data/2024/*.parquet", duckdb.ParquetImportOptions{Credentials: creds})
+	require.NoError(t, err)
+
+	joined := strings.Join(statements, "\n")
+	assert.Contains(t, joined, "LOAD httpfs")
+	assert.Contains(t, joined, "CREATE OR REPLACE SECRET gorm_duckdb_s3_sensor_data (TYPE S3, KEY_ID 'AKIDEXAMPLE', SECRET 's3cr3t-value', REGION 'eu-west-1', SCOPE 's3://sensor-data')")
+	assert.Contains(t, joined, `COPY "copy_readings" FROM 's3://sensor-data/2024/*.parquet' (FORMAT PARQUET)`)
+	assert.Less(t, strings.Index(joined, "CREATE OR REPLACE SECRET"), strings.Index(joined, "COPY"))
+
+	assert.Contains(t, sqlLog.String(), "COPY", "the load itself is still logged")
+	assert.NotContains(t, sqlLog.String(), "s3cr3t-value")
+
+	// HTTPS paths only need httpfs; credentials are for S3
+	statements = nil
+	_, err = duckdb.ImportCSV(dryRun, &CopyReading{}, "https://example.com/readings.csv", duckdb.CSVImportOptions{Header: true})
+	require.NoError(t, err)
+	assert.Contains(t, strings.Join(statements, "\n"), "LOAD httpfs")
+	assert.NotContains(t, strings.Join(statements, "\n"), "SECRET")
+
+	_, err = duckdb.ImportCSV(dryRun, &CopyReading{}, "https://example.com/readings.csv", duckdb.CSVImportOptions{Credentials: creds})
+	assert.Error(t, err)
+	_, err = duckdb.ImportCSV(db, &CopyReading{}, writeTestFile(t, "local.csv", "1,a,1\n"), duckdb.CSVImportOptions{Credentials: creds})
+	assert.Error(t, err)
+}
+
+func TestCopyFrom(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CopyReading{}))
+
+	csvPath := writeTestFile(t, "readings.csv", "id;sensor;value\n1;a;1.5\n2;b;2.5\n3;c;3.5\n")
+	n, err := duckdb.CopyFrom(db, "copy_readings", csvPath, duckdb.CopyOptions{Header: true, Delimiter: ";"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	// A column subset loads the file's columns in order; the rest stay NULL
+	subsetPath := writeTestFile(t, "subset.csv", "4,d\n5,e\n")
+	n, err = duckdb.CopyFrom(db, "copy_readings", subsetPath, duckdb.CopyOptions{Columns: []string{"id", "sensor"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	jsonPath := writeTestFile(t, "readings.json", `{"id": 6, "sensor": "f", "value": 6.5}`+"\n")
+	n, err = duckdb.CopyFrom(db, "copy_readings", jsonPath, duckdb.CopyOptions{Format: duckdb.CopyFormatJSON})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	var total, nullValues int64
+	require.NoError(t, db.Model(&CopyReading{}).Count(&total).Error)
+	assert.Equal(t, int64(6), total)
+	require.NoError(t, db.Model(&CopyReading{}).Where("value IS NULL").Count(&nullValues).Error)
+	assert.Equal(t, int64(2), nullValues)
+
+	// Quotes in the path are escaped rather than ending the literal
+	_, err = duckdb.CopyFrom(db, "copy_readings", filepath.Join(t.TempDir(), "x'); DROP TABLE copy_readings; --.csv"), duckdb.CopyOptions{})
+	require.Error(t, err)
+	assert.True(t, db.Migrator().HasTable(&CopyReading{}))
+
+	_, err = duckdb.CopyFrom(db, `copy_readings" (id) FROM 'x`, csvPath, duckdb.CopyOptions{})
+	require.Error(t, err)
+	_, err = duckdb.CopyFrom(db, "copy_readings", csvPath, duckdb.CopyOptions{Format: "XLSX"})
+	require.Error(t, err)
+	_, err = duckdb.CopyFrom(db, "copy_readings", csvPath, duckdb.CopyOptions{Format: duckdb.CopyFormatParquet, Header: true})
+	require.Error(t, err)
+}
+
+func TestCopyTo(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CopyReading{}))
+	require.NoError(t, db.Create(&[]CopyReading{
+		{ID: 1, Sensor: "a", Value: 1.5},
+		{ID: 2, Sensor: "b's", Value: 2.5},
+		{ID: 3, Sensor: "a", Value: 3.5},
+		{ID: 4, Sensor: "c", Value: 4.5},
+	}).Error)
+
+	parquetPath := filepath.Join(t.TempDir(), "readings.parquet")
+	query := db.Model(&CopyReading{}).Where("sensor IN ? AND value > ?", []string{"a", "b's"}, 2.0).Order("id")
+	require.NoError(t, duckdb.CopyTo(db, query, parquetPath, duckdb.CopyOptions{Format: duckdb.CopyFormatParquet}))
+
+	var exported []CopyReading
+	require.NoError(t, db.Raw("SELECT * FROM read_parquet(?) ORDER BY id", parquetPath).Scan(&exported).Error)
+	assert.Equal(t, []CopyReading{{ID: 2, Sensor: "b's", Value: 2.5}, {ID: 3, Sensor: "a", Value: 3.5}}, exported)
+
+	// Raw queries and a column subset, written as CSV with a header
+	csvPath := filepath.Join(t.TempDir(), "sensors.csv")
+	raw := db.Raw("SELECT * FROM copy_readings WHERE sensor = ? ORDER BY id", "a")
+	require.NoError(t, duckdb.CopyTo(db, raw, csvPath, duckdb.CopyOptions{Header: true, Columns: []string{"id", "value"}}))
+	content, err := os.ReadFile(csvPath)
+	require.NoError(t, err)
+	assert.Equal(t, "id,value\n1,1.5\n3,3.5\n", string(content))
+
+	// A ? inside a string literal is not a placeholder
+	literalPath := filepath.Join(t.TempDir(), "literal.csv")
+	require.NoError(t, duckdb.CopyTo(db, db.Raw("SELECT '?' AS mark, ? AS n", 7), literalPath, duckdb.CopyOptions{}))
+	content, err = os.ReadFile(literalPath)
+	require.NoError(t, err)
+	assert.Equal(t, "?,7\n", string(content))
+
+	err = duckdb.CopyTo(db, query, filepath.Join(t.TempDir(), "x.json"), duckdb.CopyOptions{Format: duckdb.CopyFormatJSON, Delimiter: ";"})
+	require.Error(t, err)
+}
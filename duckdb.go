@@ -4,9 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -44,7 +48,74 @@ type Config struct {
 	// DisableTransactionWorkaround controls whether to disable the transaction workaround
 	// Set to true to disable the transaction workaround if it causes issues
 	// Default: false (apply workaround)
+	//
+	// DuckDB aborts a transaction when one of its statements fails at run time
+	// (a constraint violation, a failed cast), and a later COMMIT then succeeds
+	// while silently discarding every change. The workaround checks the
+	// transaction before committing and, if it was aborted, rolls it back and
+	// returns ErrTransactionAborted, so code that ignored the failed statement
+	// does not report a commit that lost its writes. The check costs one
+	// trivial query per commit. It is ignored when Conn is set.
 	DisableTransactionWorkaround *bool
+
+	// Connector opens the database through a pre-built connector (e.g. a
+	// go-duckdb Connector with bootstrap queries) instead of parsing DSN.
+	// Connections are still wrapped by the converting driver. Ignored when Conn is set.
+	Connector driver.Connector
+
+	// SearchPath sets DuckDB's search_path (comma-separated schemas) on every
+	// connection of the pool opened from DSN, so unqualified tables are created
+	// and resolved in the first schema. Missing schemas are created. With Conn
+	// or Connector it is set once, so the pool must hold a single connection.
+	SearchPath string
+
+	// DefaultTimeType is the column type DataTypeOf uses for time.Time fields
+	// without a type tag: "TIMESTAMP" (the default) or "TIMESTAMPTZ". Times are
+	// bound as instants either way; TIMESTAMPTZ columns keep that instant
+	// unambiguous whatever the session TimeZone, and scan back in UTC.
+	DefaultTimeType string
+
+	// NativeUUID maps fields typed uuid.UUID and fields using the uuid
+	// serializer to UUID columns when they have no type tag. Without it they
+	// need gorm:"type:uuid". Enabling it on an existing database changes the
+	// expected type of such columns, so AutoMigrate alters VARCHAR columns
+	// created before to UUID; that fails if a stored value is not a UUID.
+	NativeUUID bool
+
+	// SpatialGeometry binds GEOMETRYType values through ST_GeomFromText so they
+	// can be written to GEOMETRY columns. It needs the spatial extension loaded,
+	// through ExtensionHelper.EnableSpatial or a "LOAD spatial" boot query.
+	// Without it geometries are bound as WKT text.
+	SpatialGeometry bool
+
+	// MaxOpenConns, MaxIdleConns and ConnMaxLifetime configure the pool opened
+	// by Initialize. It defaults to a single connection, which serializes every
+	// query and keeps per-connection session state (SET, temporary tables) in
+	// one place. DuckDB runs concurrent readers within one process, so a larger
+	// pool speeds up parallel reads, but session settings then only apply to
+	// the connection they ran on (use SearchPath or BootQueries to set them on
+	// every connection), and concurrent writes to the same rows may fail with
+	// transaction conflicts. MaxIdleConns defaults to MaxOpenConns. They are
+	// ignored when Conn is set.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+
+	// Settings are DuckDB configuration options (e.g. "threads", "memory_limit",
+	// "access_mode", "temp_directory") applied when the database opens, as the
+	// DSN's ?option=value query string does; options in the DSN take precedence.
+	// Startup-only options such as access_mode can only be set this way.
+	Settings map[string]string
+
+	// BootQueries run on every new connection of the pool, before it is used,
+	// for session state such as SET TimeZone or ATTACH.
+	BootQueries []string
+
+	// ReadOnly opens the database with access_mode=READ_ONLY, so an existing
+	// file can be queried without taking its write lock. AutoMigrate then
+	// only checks that the tables exist, and writes fail with ErrReadOnly.
+	// SearchPath is set without creating schemas, so it must name existing ones.
+	ReadOnly bool
 }
 
 // Open creates a new DuckDB dialector with the given DSN.
@@ -70,6 +141,12 @@ func OpenWithRowCallbackWorkaround(dsn string, enableWorkaround bool) gorm.Diale
 	}}
 }
 
+// OpenReadOnly creates a DuckDB dialector that opens the database file at
+// path read-only. Several processes can open the same file this way.
+func OpenReadOnly(path string) gorm.Dialector {
+	return &Dialector{Config: &Config{DSN: path, ReadOnly: true}}
+}
+
 // New creates a new DuckDB dialector with the given configuration.
 func New(config Config) gorm.Dialector {
 	return &Dialector{Config: &config}
@@ -96,11 +173,162 @@ func (d *convertingDriver) Open(name string) (driver.Conn, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &convertingConn{conn}, nil
+	return &convertingConn{Conn: conn}, nil
+}
+
+// OpenConnector opens the DSN once per pool, so every connection of the pool
+// shares one database; opening each connection separately would give each its
+// own in-memory database
+func (d *convertingDriver) OpenConnector(name string) (driver.Connector, error) {
+	driverContext, ok := d.Driver.(driver.DriverContext)
+	if !ok {
+		return &dsnConnector{driver: d, name: name}, nil
+	}
+	connector, err := driverContext.OpenConnector(name)
+	if err != nil {
+		return nil, err
+	}
+	return &closingConnector{convertingConnector{Connector: connector}}, nil
+}
+
+// dsnConnector opens connections through Driver.Open for drivers without
+// OpenConnector
+type dsnConnector struct {
+	driver *convertingDriver
+	name   string
+}
+
+func (c *dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.name)
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// closingConnector closes the database it opened when the pool is closed
+type closingConnector struct {
+	convertingConnector
+}
+
+func (c *closingConnector) Close() error {
+	if closer, ok := c.Connector.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// convertingConnector wraps a user-supplied connector so its connections get
+// the same value conversion and error translation as DSN-opened ones
+type convertingConnector struct {
+	driver.Connector
+
+	// rawCommit turns off the aborted-transaction check on commit
+	// (Config.DisableTransactionWorkaround)
+	rawCommit bool
+}
+
+func (c *convertingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &convertingConn{Conn: conn, rawCommit: c.rawCommit}, nil
+}
+
+func (c *convertingConnector) Driver() driver.Driver {
+	return &convertingDriver{c.Connector.Driver()}
 }
 
 type convertingConn struct {
 	driver.Conn
+
+	// rawCommit commits transactions without checking whether DuckDB
+	// aborted them
+	rawCommit bool
+}
+
+// ErrIsolationLevelNotSupported is returned when a transaction requests an
+// isolation level DuckDB does not offer. DuckDB runs every transaction under
+// snapshot isolation, so only sql.LevelDefault and sql.LevelSnapshot are accepted.
+var ErrIsolationLevelNotSupported = errors.New("isolation level not supported by DuckDB")
+
+// ErrReadOnly is wrapped by the errors of statements that would write to a
+// database opened read-only (see OpenReadOnly).
+var ErrReadOnly = errors.New("database is opened read-only")
+
+// ErrTransactionAborted is returned by Commit when a statement of the
+// transaction failed and DuckDB aborted it; the transaction is rolled back
+// instead of committed. See Config.DisableTransactionWorkaround.
+var ErrTransactionAborted = errors.New("transaction was aborted by a failed statement and has been rolled back")
+
+// BeginTx starts a transaction. ReadOnly maps to BEGIN TRANSACTION READ ONLY,
+// under which writes fail; the accepted isolation levels are no-ops.
+func (c *convertingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	switch level := sql.IsolationLevel(opts.Isolation); level {
+	case sql.LevelDefault, sql.LevelSnapshot:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrIsolationLevelNotSupported, level)
+	}
+
+	tx, err := c.beginTx(ctx, opts.ReadOnly)
+	if err != nil || c.rawCommit {
+		return tx, err
+	}
+	return &checkedTx{Tx: tx, conn: c}, nil
+}
+
+func (c *convertingConn) beginTx(ctx context.Context, readOnly bool) (driver.Tx, error) {
+	if !readOnly {
+		if beginTx, ok := c.Conn.(driver.ConnBeginTx); ok {
+			return beginTx.BeginTx(ctx, driver.TxOptions{})
+		}
+		return c.Conn.Begin() //nolint:staticcheck // fallback for drivers without BeginTx
+	}
+
+	if _, err := c.ExecContext(ctx, "BEGIN TRANSACTION READ ONLY", nil); err != nil {
+		return nil, err
+	}
+	return &readOnlyTx{c}, nil
+}
+
+// checkedTx refuses to commit a transaction DuckDB has aborted. DuckDB
+// accepts COMMIT for it but discards its changes, so Commit first runs a
+// trivial query, which fails in an aborted transaction, and rolls back then.
+type checkedTx struct {
+	driver.Tx
+	conn *convertingConn
+}
+
+func (t *checkedTx) Commit() error {
+	if queryer, ok := t.conn.Conn.(driver.QueryerContext); ok {
+		rows, err := queryer.QueryContext(context.Background(), "SELECT 1", nil)
+		if err == nil {
+			err = rows.Close()
+		}
+		if err != nil && strings.Contains(err.Error(), "transaction is aborted") {
+			if rollbackErr := t.Tx.Rollback(); rollbackErr != nil {
+				return errors.Join(ErrTransactionAborted, rollbackErr)
+			}
+			return ErrTransactionAborted
+		}
+	}
+	return t.Tx.Commit()
+}
+
+// readOnlyTx ends a transaction started with BEGIN TRANSACTION READ ONLY
+type readOnlyTx struct {
+	conn *convertingConn
+}
+
+func (t *readOnlyTx) Commit() error {
+	_, err := t.conn.ExecContext(context.Background(), "COMMIT", nil)
+	return err
+}
+
+func (t *readOnlyTx) Rollback() error {
+	_, err := t.conn.ExecContext(context.Background(), "ROLLBACK", nil)
+	return err
 }
 
 func (c *convertingConn) Prepare(query string) (driver.Stmt, error) {
@@ -414,6 +642,39 @@ func (dialector Dialector) Initialize(db *gorm.DB) error {
 			}
 		}
 
+		// Qualify tables of models living in an attached database
+		for _, register := range []func() error{
+			func() error {
+				return db.Callback().Create().Before("gorm:create").Register("duckdb:qualify_database", qualifyDatabaseCallback)
+			},
+			func() error {
+				return db.Callback().Query().Before("gorm:query").Register("duckdb:qualify_database", qualifyDatabaseCallback)
+			},
+			func() error {
+				return db.Callback().Update().Before("gorm:update").Register("duckdb:qualify_database", qualifyDatabaseCallback)
+			},
+			func() error {
+				return db.Callback().Delete().Before("gorm:delete").Register("duckdb:qualify_database", qualifyDatabaseCallback)
+			},
+			func() error {
+				return db.Callback().Row().Before("gorm:row").Register("duckdb:qualify_database", qualifyDatabaseCallback)
+			},
+		} {
+			if err := register(); err != nil {
+				if !strings.Contains(strings.ToLower(err.Error()), "duplicated") && !strings.Contains(strings.ToLower(err.Error()), "already") {
+					return fmt.Errorf("failed to register database qualifier callback: %w", err)
+				}
+			}
+		}
+
+		// Register the raw callback so db.Exec() reaches the connection. Without it
+		// the Raw processor has no callbacks and Exec silently does nothing.
+		if err := db.Callback().Raw().Replace("gorm:raw", callbacks.RawExec); err != nil {
+			if !strings.Contains(strings.ToLower(err.Error()), "duplicated") && !strings.Contains(strings.ToLower(err.Error()), "already") {
+				return fmt.Errorf("failed to replace raw callback: %w", err)
+			}
+		}
+
 		// Replace the row callback with our DuckDB-compatible version
 		// This is a workaround for a GORM bug where the default RowQuery callback
 		// fails to properly assign Statement.Dest, causing Raw().Row() to return nil.
@@ -442,23 +703,68 @@ func (dialector Dialector) Initialize(db *gorm.DB) error {
 		dialector.DefaultStringSize = 256
 	}
 
+	if _, err := timeColumnType(dialector.DefaultTimeType); err != nil {
+		return err
+	}
+
 	if dialector.DriverName == "" {
 		dialector.DriverName = "duckdb-gorm"
 	}
 
+	if (len(dialector.Settings) > 0 || len(dialector.BootQueries) > 0 || dialector.ReadOnly) && (dialector.Conn != nil || dialector.Connector != nil) {
+		return fmt.Errorf("settings, boot queries and read-only mode need the database to be opened from DSN, not Conn or Connector")
+	}
+
 	if dialector.Conn != nil {
 		db.ConnPool = dialector.Conn
 	} else {
-		connPool, err := sql.Open(dialector.DriverName, dialector.DSN)
-		if err != nil {
-			return fmt.Errorf("failed to open database connection: %w", err)
+		var connPool *sql.DB
+		if dialector.Connector != nil {
+			connPool = sql.OpenDB(&convertingConnector{Connector: dialector.Connector, rawCommit: dialector.rawCommit()})
+		} else if len(dialector.Settings) > 0 || len(dialector.BootQueries) > 0 || dialector.SearchPath != "" || dialector.rawCommit() {
+			connector, err := dialector.bootConnector()
+			if err != nil {
+				return fmt.Errorf("failed to open database connection: %w", err)
+			}
+			connPool = sql.OpenDB(connector)
+		} else {
+			var err error
+			connPool, err = sql.Open(dialector.DriverName, dialector.dsn())
+			if err != nil {
+				return fmt.Errorf("failed to open database connection: %w", err)
+			}
 		}
 		db.ConnPool = connPool
 
 		// Set connection pool settings to ensure proper transaction handling
 		if sqlDB, ok := db.ConnPool.(*sql.DB); ok {
-			sqlDB.SetMaxOpenConns(1) // DuckDB is embedded, so we should only have one connection
-			sqlDB.SetMaxIdleConns(1)
+			// DuckDB is embedded, so one connection is the default
+			maxOpen, maxIdle := 1, dialector.MaxIdleConns
+			if dialector.MaxOpenConns > 0 {
+				maxOpen = dialector.MaxOpenConns
+			}
+			if maxIdle <= 0 {
+				maxIdle = maxOpen
+			}
+			sqlDB.SetMaxOpenConns(maxOpen)
+			sqlDB.SetMaxIdleConns(maxIdle)
+			if dialector.ConnMaxLifetime > 0 {
+				sqlDB.SetConnMaxLifetime(dialector.ConnMaxLifetime)
+			}
+		}
+	}
+
+	if dialector.SearchPath != "" {
+		if dialector.Conn == nil && dialector.Connector == nil {
+			// bootConnector sets it on each connection; connect now so a
+			// bad search path fails here rather than on the first query
+			if err := db.ConnPool.(*sql.DB).PingContext(context.Background()); err != nil {
+				return err
+			}
+		} else if !singleConnection(db.ConnPool) || (dialector.Connector != nil && dialector.MaxOpenConns > 1) {
+			return fmt.Errorf("search path with Conn or Connector needs a single-connection pool")
+		} else if err := applySearchPath(context.Background(), db.ConnPool, strings.Split(dialector.SearchPath, ","), !dialector.ReadOnly); err != nil {
+			return err
 		}
 	}
 
@@ -468,6 +774,329 @@ func (dialector Dialector) Initialize(db *gorm.DB) error {
 	return nil
 }
 
+// dsn returns the DSN with the read-only access mode added when ReadOnly is set
+func (dialector Dialector) dsn() string {
+	if dialector.ReadOnly {
+		return dsnWithOption(dialector.DSN, "access_mode", "READ_ONLY")
+	}
+	return dialector.DSN
+}
+
+// rawCommit reports whether DisableTransactionWorkaround is set
+func (dialector Dialector) rawCommit() bool {
+	return dialector.DisableTransactionWorkaround != nil && *dialector.DisableTransactionWorkaround
+}
+
+// bootConnector opens the DSN with Settings added to its options and
+// BootQueries, then the SearchPath statements, run on each new connection.
+// It is also used to open the DSN with the transaction workaround disabled.
+func (dialector Dialector) bootConnector() (driver.Connector, error) {
+	dsn := dialector.dsn()
+	names := make([]string, 0, len(dialector.Settings))
+	for name := range dialector.Settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dsn = dsnWithOption(dsn, name, dialector.Settings[name])
+	}
+
+	bootQueries := dialector.BootQueries
+	if dialector.SearchPath != "" {
+		queries, err := searchPathQueries(strings.Split(dialector.SearchPath, ","), !dialector.ReadOnly)
+		if err != nil {
+			return nil, err
+		}
+		bootQueries = append(append([]string(nil), bootQueries...), queries...)
+	}
+	connector, err := duckdb.NewConnector(dsn, func(execer driver.ExecerContext) error {
+		for _, query := range bootQueries {
+			if _, err := execer.ExecContext(context.Background(), query, nil); err != nil {
+				return fmt.Errorf("boot query %q failed: %w", query, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &closingConnector{convertingConnector{Connector: connector, rawCommit: dialector.rawCommit()}}, nil
+}
+
+// SetSearchPath points the session's search_path at schemas, creating any that
+// do not exist yet. Unqualified table names then resolve to the first schema.
+// The setting belongs to one connection, so db must be a transaction or use a
+// single-connection pool; set Config.SearchPath to cover a larger pool.
+func SetSearchPath(db *gorm.DB, schemas ...string) error {
+	if !singleConnection(db.Statement.ConnPool) {
+		return fmt.Errorf("SetSearchPath needs a transaction or a single-connection pool, use Config.SearchPath instead")
+	}
+	return applySearchPath(db.Statement.Context, db.Statement.ConnPool, schemas, true)
+}
+
+// singleConnection reports whether every statement run on pool uses the same
+// connection
+func singleConnection(pool gorm.ConnPool) bool {
+	if prepared, ok := pool.(*gorm.PreparedStmtDB); ok {
+		pool = prepared.ConnPool
+	}
+	if sqlDB, ok := pool.(*sql.DB); ok {
+		return sqlDB.Stats().MaxOpenConnections == 1
+	}
+	return true
+}
+
+func applySearchPath(ctx context.Context, pool gorm.ConnPool, schemas []string, create bool) error {
+	queries, err := searchPathQueries(schemas, create)
+	if err != nil {
+		return err
+	}
+	for _, query := range queries {
+		if _, err := pool.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to set search path: %w", err)
+		}
+	}
+	return nil
+}
+
+// searchPathQueries returns the statements that set search_path to schemas,
+// preceded by the ones creating them when create is set
+func searchPathQueries(schemas []string, create bool) ([]string, error) {
+	var queries []string
+	names := make([]string, 0, len(schemas))
+	for _, schema := range schemas {
+		if schema = strings.TrimSpace(schema); schema == "" {
+			continue
+		}
+		if create {
+			var quoted strings.Builder
+			Dialector{}.QuoteTo(&quoted, schema)
+			queries = append(queries, "CREATE SCHEMA IF NOT EXISTS "+quoted.String())
+		}
+		names = append(names, schema)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("search path has no schemas")
+	}
+	return append(queries, "SET search_path = "+quoteLiteral(strings.Join(names, ","))), nil
+}
+
+// Attach attaches the database file at path (":memory:" for a new in-memory
+// database) under alias, so its tables can be queried as alias.table or
+// alias.schema.table alongside the main database. With readOnly the database
+// is attached READ_ONLY.
+func Attach(db *gorm.DB, path, alias string, readOnly bool) error {
+	if alias == "" {
+		return fmt.Errorf("attach needs an alias for %s", path)
+	}
+	sql := "ATTACH " + quoteLiteral(path) + " AS " + db.Statement.Quote(alias)
+	if readOnly {
+		sql += " (READ_ONLY)"
+	}
+	if err := db.Exec(sql).Error; err != nil {
+		return fmt.Errorf("failed to attach %s as %s: %w", path, alias, err)
+	}
+	return nil
+}
+
+// Detach detaches the database attached under alias
+func Detach(db *gorm.DB, alias string) error {
+	if err := db.Exec("DETACH " + db.Statement.Quote(alias)).Error; err != nil {
+		return fmt.Errorf("failed to detach %s: %w", alias, err)
+	}
+	return nil
+}
+
+// databaseSettingKey stores the UseDatabase alias in Statement.Settings
+const databaseSettingKey = "duckdb:database"
+
+// UseDatabase qualifies the statement's table with an attached database alias,
+// so db.Clauses(duckdb.UseDatabase("crm")).Find(&customers) reads from
+// "crm"."customers". A model can instead declare its database with a
+// DatabaseName() string method, like gorm's TableName.
+func UseDatabase(name string) clause.Expression {
+	return useDatabase(name)
+}
+
+type useDatabase string
+
+// ModifyStatement implements gorm.StatementModifier
+func (u useDatabase) ModifyStatement(stmt *gorm.Statement) {
+	stmt.Settings.Store(databaseSettingKey, string(u))
+}
+
+// Build implements clause.Expression; the clause only modifies the statement
+func (useDatabase) Build(clause.Builder) {}
+
+// qualifyDatabaseCallback prefixes the statement table with the database named
+// by UseDatabase or by the model's DatabaseName method.
+func qualifyDatabaseCallback(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Table == "" {
+		return
+	}
+
+	var name string
+	if value, ok := stmt.Settings.Load(databaseSettingKey); ok {
+		name, _ = value.(string)
+	} else if stmt.Schema != nil {
+		if namer, ok := reflect.New(stmt.Schema.ModelType).Interface().(interface{ DatabaseName() string }); ok {
+			name = namer.DatabaseName()
+		}
+	}
+	if name == "" {
+		return
+	}
+
+	// db.Table("t") quotes the name into TableExpr; other table expressions
+	// (aliases, subqueries) are left as written. A reused statement may
+	// already carry the prefix.
+	if expr := stmt.TableExpr; expr != nil {
+		quoted := stmt.Quote(name) + "."
+		if len(expr.Vars) == 0 && strings.HasPrefix(expr.SQL, `"`) && !strings.Contains(expr.SQL, " ") && !strings.HasPrefix(expr.SQL, quoted) {
+			stmt.TableExpr = &clause.Expr{SQL: quoted + expr.SQL}
+		}
+		return
+	}
+	if !strings.HasPrefix(stmt.Table, name+".") {
+		stmt.Table = name + "." + stmt.Table
+	}
+}
+
+// Setting is one row of duckdb_settings()
+type Setting struct {
+	Name        string
+	Value       string
+	Description string
+	InputType   string
+	Scope       string
+}
+
+// AllSettings returns the effective value of every DuckDB setting, ordered by
+// name. Unset values are returned as empty strings.
+func AllSettings(db *gorm.DB) ([]Setting, error) {
+	var settings []Setting
+	err := db.Raw(`SELECT name, COALESCE(value, '') AS value, COALESCE(description, '') AS description,
+			COALESCE(input_type, '') AS input_type, COALESCE(scope, '') AS scope
+		FROM duckdb_settings() ORDER BY name`).Scan(&settings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+	return settings, nil
+}
+
+// DictEntry is one column of DataDictionary
+type DictEntry struct {
+	Schema       string
+	Table        string
+	Column       string
+	Type         string
+	Comment      string
+	TableComment string
+}
+
+// DataDictionary lists every column of the user tables in the current
+// database with its type and the comments set by COMMENT ON, ordered by
+// schema, table and column position. Missing comments are empty strings.
+func DataDictionary(db *gorm.DB) ([]DictEntry, error) {
+	var entries []DictEntry
+	err := db.Raw(`SELECT c.schema_name AS schema, c.table_name AS "table", c.column_name AS "column",
+			c.data_type AS type, COALESCE(c.comment, '') AS comment, COALESCE(t.comment, '') AS table_comment
+		FROM duckdb_columns() c
+		JOIN duckdb_tables() t ON t.table_oid = c.table_oid
+		WHERE NOT c.internal AND c.database_name = current_database()
+		ORDER BY c.schema_name, c.table_name, c.column_index`).Scan(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data dictionary: %w", err)
+	}
+	return entries, nil
+}
+
+var sequenceNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*){0,2}$`)
+
+// NextVal advances sequence and returns its new value, e.g. to allocate an ID
+// before inserting rows that reference it. sequence may be schema-qualified.
+func NextVal(db *gorm.DB, sequence string) (int64, error) {
+	return sequenceValue(db, "nextval", sequence)
+}
+
+// CurrVal returns the value most recently produced by nextval for sequence on
+// the current connection. Run it in the same transaction as the NextVal call,
+// since the pool may otherwise hand out a different connection.
+func CurrVal(db *gorm.DB, sequence string) (int64, error) {
+	return sequenceValue(db, "currval", sequence)
+}
+
+func sequenceValue(db *gorm.DB, function, sequence string) (int64, error) {
+	if !sequenceNamePattern.MatchString(sequence) {
+		return 0, fmt.Errorf("invalid sequence name %q", sequence)
+	}
+
+	var value int64
+	if err := db.Raw(fmt.Sprintf("SELECT %s(%s)", function, quoteLiteral(sequence))).Scan(&value).Error; err != nil {
+		return 0, fmt.Errorf("failed to read %s of sequence %s: %w", function, sequence, err)
+	}
+	return value, nil
+}
+
+// Capabilities accepted by SupportsFeature
+const (
+	// FeatureAddPrimaryKey is ALTER TABLE ... ADD PRIMARY KEY on an existing table.
+	FeatureAddPrimaryKey = "alter_add_primary_key"
+	// FeatureReadOnlyTransactions is BEGIN TRANSACTION READ ONLY.
+	FeatureReadOnlyTransactions = "read_only_transactions"
+	// FeatureJSON is the json extension's functions being available.
+	FeatureJSON = "json"
+)
+
+// Version returns the DuckDB library version, e.g. "v1.3.2".
+func Version(db *gorm.DB) (string, error) {
+	var version string
+	if err := db.Raw("SELECT library_version FROM pragma_version()").Scan(&version).Error; err != nil {
+		return "", fmt.Errorf("failed to read DuckDB version: %w", err)
+	}
+	return version, nil
+}
+
+// SupportsFeature reports whether the connected DuckDB supports feature, one
+// of the Feature constants. Any other name is looked up as a function name in
+// duckdb_functions(). Statement features are probed in a rolled-back
+// transaction, so callers should cache the answer rather than ask per query.
+func SupportsFeature(db *gorm.DB, feature string) bool {
+	switch feature {
+	case FeatureAddPrimaryKey:
+		return probeStatements(db, nil,
+			"CREATE TEMP TABLE gorm_duckdb_probe (id INTEGER)",
+			"ALTER TABLE gorm_duckdb_probe ADD PRIMARY KEY (id)")
+	case FeatureReadOnlyTransactions:
+		return probeStatements(db, &sql.TxOptions{ReadOnly: true}, "SELECT 1")
+	case FeatureJSON:
+		return hasFunction(db, "json_extract")
+	}
+	return hasFunction(db, feature)
+}
+
+// probeStatements runs statements in a transaction that is always rolled back
+// and reports whether all of them succeeded
+func probeStatements(db *gorm.DB, opts *sql.TxOptions, statements ...string) bool {
+	errProbeDone := errors.New("probe done")
+	err := db.Session(&gorm.Session{Logger: db.Logger.LogMode(logger.Silent)}).Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return errProbeDone
+	}, opts)
+	return errors.Is(err, errProbeDone)
+}
+
+func hasFunction(db *gorm.DB, name string) bool {
+	var count int64
+	err := db.Raw("SELECT COUNT(*) FROM duckdb_functions() WHERE function_name = ?", strings.ToLower(name)).Scan(&count).Error
+	return err == nil && count > 0
+}
+
 // Migrator returns a new migrator instance for DuckDB.
 func (dialector Dialector) Migrator(db *gorm.DB) gorm.Migrator {
 	return Migrator{
@@ -478,14 +1107,36 @@ func (dialector Dialector) Migrator(db *gorm.DB) gorm.Migrator {
 				CreateIndexAfterCreateTable: true,
 			},
 		},
+		newCatalogCache(),
 	}
 }
 
+// timeColumnType validates a Config.DefaultTimeType value and returns the
+// column type it selects
+func timeColumnType(timeType string) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(timeType)) {
+	case "", "TIMESTAMP":
+		return "TIMESTAMP", nil
+	case "TIMESTAMPTZ", "TIMESTAMP WITH TIME ZONE":
+		return "TIMESTAMPTZ", nil
+	}
+	return "", fmt.Errorf("unsupported DefaultTimeType %q: use TIMESTAMP or TIMESTAMPTZ", timeType)
+}
+
 // DataTypeOf returns the SQL data type for a given field.
 func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 	if field == nil {
 		return ""
 	}
+
+	// Fields tagged enum:a,b,c use a named ENUM type created by the migrator
+	if name, _, ok := enumDefinition(field); ok {
+		return name
+	}
+	if dialector.NativeUUID && field.TagSettings["TYPE"] == "" && isUUIDField(field) {
+		return "UUID"
+	}
+
 	switch field.DataType {
 	case schema.Bool:
 		return "BOOLEAN"
@@ -536,6 +1187,11 @@ func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 		}
 		return "TEXT"
 	case schema.Time:
+		if dialector.Config != nil {
+			if timeType, err := timeColumnType(dialector.DefaultTimeType); err == nil {
+				return timeType
+			}
+		}
 		return "TIMESTAMP"
 	case schema.Bytes:
 		return "BLOB"
@@ -549,11 +1205,16 @@ func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 		case strings.Contains(typeName, "StructType"):
 			return "STRUCT"
 		case strings.Contains(typeName, "MapType"):
-			return "MAP"
+			return mapColumnType(field)
 		case strings.Contains(typeName, "ListType"):
 			return "LIST"
+		case strings.Contains(typeName, "VectorType"):
+			if field.Size > 0 {
+				return fmt.Sprintf("FLOAT[%d]", field.Size)
+			}
+			return string(field.DataType)
 		case strings.Contains(typeName, "DecimalType"):
-			return "DECIMAL(18,6)" // Default precision and scale
+			return decimalColumnType(field)
 		case strings.Contains(typeName, "IntervalType"):
 			return "INTERVAL"
 		case strings.Contains(typeName, "UUIDType"):
@@ -562,12 +1223,20 @@ func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 			return "JSON"
 		// Phase 3A: Core advanced types for 100% DuckDB utilization
 		case strings.Contains(typeName, "ENUMType"):
-			return "ENUM" // Will be expanded with enum definition
+			// A type tag names an existing enum type (e.g. gorm:"type:mood")
+			if field.DataType != "" && !strings.EqualFold(string(field.DataType), "ENUM") {
+				return string(field.DataType)
+			}
+			// The migrator creates this type from the model's ENUMType value
+			return enumTypeName(field, "")
 		case strings.Contains(typeName, "UNIONType"):
 			return "UNION" // Supports variant data types
 		case strings.Contains(typeName, "TimestampTZType"):
 			return "TIMESTAMPTZ" // Timezone-aware timestamps
 		case strings.Contains(typeName, "HugeIntType"):
+			if strings.EqualFold(string(field.DataType), "UHUGEINT") {
+				return "UHUGEINT"
+			}
 			return "HUGEINT" // 128-bit integers
 		case strings.Contains(typeName, "BitStringType"):
 			return "BIT" // Bit strings and boolean arrays
@@ -593,12 +1262,65 @@ func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 	// Check if it's an array type
 	if strings.HasSuffix(string(field.DataType), "[]") {
 		baseType := strings.TrimSuffix(string(field.DataType), "[]")
+		// Precision/scale tags size DECIMAL elements, e.g. DecimalArray fields
+		if strings.EqualFold(baseType, "DECIMAL") && field.Precision > 0 {
+			baseType = fmt.Sprintf("DECIMAL(%d,%d)", field.Precision, field.Scale)
+		}
 		return fmt.Sprintf("%s[]", baseType)
 	}
 
 	return string(field.DataType)
 }
 
+// referencedPrimaryKey returns the primary key that field refers to as the
+// foreign key of a relationship of its schema, or nil. Has-one and has-many
+// relationships are registered on the referencing schema as well, so both
+// sides of a relationship are found.
+func referencedPrimaryKey(field *schema.Field) *schema.Field {
+	if field.Schema == nil {
+		return nil
+	}
+	field.Schema.Relationships.Mux.RLock()
+	defer field.Schema.Relationships.Mux.RUnlock()
+	for _, rel := range field.Schema.Relationships.Relations {
+		for _, ref := range rel.References {
+			if ref.ForeignKey == field && ref.PrimaryKey != nil && ref.PrimaryKey != field {
+				return ref.PrimaryKey
+			}
+		}
+	}
+	return nil
+}
+
+// enumDefinition returns the ENUM type name and values for a field tagged
+// enum:a,b,c. The name comes from the type tag, defaulting to <table>_<column>.
+func enumDefinition(field *schema.Field) (string, []string, bool) {
+	tag, ok := field.TagSettings["ENUM"]
+	if !ok || strings.TrimSpace(tag) == "" {
+		return "", nil, false
+	}
+
+	var values []string
+	for _, v := range strings.Split(tag, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return enumTypeName(field, field.TagSettings["TYPE"]), values, true
+}
+
+// enumTypeName returns name, or <table>_<column> when it is empty
+func enumTypeName(field *schema.Field, name string) string {
+	if name = strings.TrimSpace(name); name != "" {
+		return name
+	}
+	if field.Schema != nil && field.Schema.Table != "" {
+		return field.Schema.Table + "_" + field.DBName
+	}
+	return field.DBName
+}
+
 // DefaultValueOf returns the default value clause for a field.
 func (dialector Dialector) DefaultValueOf(field *schema.Field) clause.Expression {
 	if field.HasDefaultValue && (field.DefaultValueInterface != nil || field.DefaultValue != "") {
@@ -684,14 +1406,43 @@ func (dialector Dialector) Explain(sql string, vars ...interface{}) string {
 	return logger.ExplainSQL(sql, nil, `"`, vars...)
 }
 
+// ErrSavePointNotSupported is returned by the savepoint methods when DuckDB
+// has no SAVEPOINT statement. gorm uses savepoints for nested Transaction
+// calls, so those fail with it; set gorm.Config.DisableNestedTransaction to
+// run nested calls as part of the outer transaction instead.
+var ErrSavePointNotSupported = errors.New("savepoints are not supported by this DuckDB version")
+
+var savePointNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // SavePoint creates a savepoint with the given name.
 func (dialector Dialector) SavePoint(tx *gorm.DB, name string) error {
-	return tx.Exec("SAVEPOINT " + name).Error
+	return execSavePoint(tx, "SAVEPOINT ", name)
 }
 
 // RollbackTo rolls back to the given savepoint.
 func (dialector Dialector) RollbackTo(tx *gorm.DB, name string) error {
-	return tx.Exec("ROLLBACK TO SAVEPOINT " + name).Error
+	return execSavePoint(tx, "ROLLBACK TO SAVEPOINT ", name)
+}
+
+// ReleaseSavePoint releases the given savepoint, keeping its changes. DuckDB
+// cannot create savepoints, so it currently always fails.
+func (dialector Dialector) ReleaseSavePoint(tx *gorm.DB, name string) error {
+	return execSavePoint(tx, "RELEASE SAVEPOINT ", name)
+}
+
+// execSavePoint runs a savepoint statement for name, which must be a plain
+// identifier (letters, digits and underscores) since it is spliced into SQL
+func execSavePoint(tx *gorm.DB, statement, name string) error {
+	if !savePointNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid savepoint name %q", name)
+	}
+	if err := tx.Exec(statement + tx.Statement.Quote(name)).Error; err != nil {
+		if strings.Contains(err.Error(), "Parser Error: syntax error") {
+			return fmt.Errorf("%s%s: %w", statement, name, ErrSavePointNotSupported)
+		}
+		return err
+	}
+	return nil
 }
 
 // Translate implements ErrorTranslator interface for built-in error translation
@@ -711,91 +1462,91 @@ func createCallback(db *gorm.DB) {
 		return
 	}
 
+	var autoIncrementField *schema.Field
+	var records []reflect.Value
 	if db.Statement.Schema != nil {
-		var hasAutoIncrement bool
-		var autoIncrementField *schema.Field
-
 		// Check if we have auto-increment primary key
 		for _, field := range db.Statement.Schema.PrimaryFields {
 			if field.AutoIncrement {
-				hasAutoIncrement = true
 				autoIncrementField = field
 				break
 			}
 		}
+		// Upserts (ON CONFLICT, INSERT OR IGNORE/REPLACE) take the generic path:
+		// DuckDB's RETURNING then yields the sequence values drawn for updated
+		// rows rather than their IDs, and no row for skipped ones, so IDs
+		// cannot be matched to records
+		_, upsert := db.Statement.Clauses["ON CONFLICT"]
+		if insert, ok := db.Statement.Clauses["INSERT"].Expression.(clause.Insert); ok && insert.Modifier != "" {
+			upsert = true
+		}
+		if autoIncrementField != nil && !upsert {
+			records = createRecords(db.Statement)
+		}
+	}
 
-		if hasAutoIncrement {
-			// Build custom INSERT with RETURNING
-			sql, vars := buildInsertSQL(db, autoIncrementField)
-			if sql != "" {
-				// Execute with RETURNING to get the auto-generated ID
-				var id int64
-				// Check if there's an error in the query before trying to get the row
-				rawDB := db.Raw(sql, vars...)
-				if rawDB.Error != nil {
-					if addErr := db.AddError(rawDB.Error); addErr != nil {
-						return
-					}
-					return
-				}
+	if db.Statement.SQL.String() == "" {
+		db.Statement.AddClauseIfNotExists(clause.Insert{})
+		db.Statement.AddClause(createValues(db.Statement, autoIncrementField))
+		if len(records) > 0 {
+			// One VALUES row per record, returning the generated IDs
+			db.Statement.AddClause(clause.Returning{Columns: []clause.Column{{Name: autoIncrementField.DBName}}})
+			db.Statement.Build("INSERT", "VALUES", "ON CONFLICT", "RETURNING")
+		} else {
+			db.Statement.Build("INSERT", "VALUES", "ON CONFLICT")
+		}
+	}
 
-				// Use GORM's Scan to safely execute the query and avoid nil Row panics
-				rows, err := rawDB.Rows()
-				if err != nil {
-					if addErr := db.AddError(err); addErr != nil {
-						return
-					}
-					return
-				}
-				if rows == nil {
-					if addErr := db.AddError(fmt.Errorf("failed to execute returning insert: nil rows")); addErr != nil {
-						return
-					}
-					return
-				}
-				defer rows.Close()
+	if len(records) > 0 {
+		if db.DryRun || db.Error != nil {
+			return
+		}
 
-				if rows.Next() {
-					if err := rows.Scan(&id); err != nil {
-						if addErr := db.AddError(err); addErr != nil {
-							return
-						}
-						return
-					}
-				} else {
-					if addErr := db.AddError(fmt.Errorf("no rows returned from RETURNING query")); addErr != nil {
-						return
-					}
+		// Run on the statement's ConnPool, which is the transaction's
+		// connection inside db.Transaction
+		rows, err := db.Statement.ConnPool.QueryContext(db.Statement.Context, db.Statement.SQL.String(), db.Statement.Vars...)
+		if err != nil {
+			if addErr := db.AddError(err); addErr != nil {
+				return
+			}
+			return
+		}
+		defer rows.Close()
+
+		// RETURNING yields the generated IDs in VALUES order
+		var count int
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				if addErr := db.AddError(err); addErr != nil {
 					return
 				}
-				// Set the ID in the model using GORM's ReflectValue
-				if db.Statement.ReflectValue.IsValid() && db.Statement.ReflectValue.CanAddr() {
-					modelValue := db.Statement.ReflectValue
-
-					if idField := modelValue.FieldByName(autoIncrementField.Name); idField.IsValid() && idField.CanSet() {
-						// Handle different integer types
-						switch idField.Kind() {
-						case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-							if id >= 0 {
-								idField.SetUint(uint64(id))
-							}
-						case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-							idField.SetInt(id)
-						}
-					} else {
-					}
-				} else {
-				}
-
-				db.Statement.RowsAffected = 1
 				return
 			}
+			if count < len(records) {
+				setAutoIncrementID(records[count], autoIncrementField, id)
+			}
+			count++
+		}
+		if err := rows.Err(); err != nil {
+			if addErr := db.AddError(err); addErr != nil {
+				return
+			}
+			return
 		}
+		if count == 0 {
+			if addErr := db.AddError(fmt.Errorf("no rows returned from RETURNING query")); addErr != nil {
+				return
+			}
+			return
+		}
+
+		db.Statement.RowsAffected = int64(count)
+		return
 	}
 
-	// Fall back to default behavior for non-auto-increment cases
-	if db.Statement.SQL.String() == "" {
-		db.Statement.Build("INSERT")
+	if db.DryRun || db.Error != nil {
+		return
 	}
 
 	// Use GORM's default create callback instead of our custom implementation
@@ -834,51 +1585,65 @@ func createCallback(db *gorm.DB) {
 	}
 }
 
-// buildInsertSQL creates an INSERT statement with RETURNING for auto-increment fields
-func buildInsertSQL(db *gorm.DB, autoIncrementField *schema.Field) (string, []interface{}) {
-	if db.Statement.Schema == nil {
-		return "", nil
-	}
-
-	fieldCount := len(db.Statement.Schema.Fields)
-	fields := make([]string, 0, fieldCount)
-	placeholders := make([]string, 0, fieldCount)
-	values := make([]interface{}, 0, fieldCount)
-
-	// Build field list excluding auto-increment field
-	for _, field := range db.Statement.Schema.Fields {
-		if field.DBName == autoIncrementField.DBName {
-			continue // Skip auto-increment field
+// createValues converts the records being created into VALUES as gorm does,
+// writing DEFAULT where a record leaves a database default (DefaultValueOf
+// has no SQL for those) and for every row of a slice with no other columns
+func createValues(stmt *gorm.Statement, autoIncrementField *schema.Field) clause.Values {
+	values := callbacks.ConvertToCreateValues(stmt)
+	for _, row := range values.Values {
+		for i, value := range row {
+			if expr, ok := value.(clause.Expr); ok && expr.SQL == "" && len(expr.Vars) == 0 {
+				row[i] = clause.Expr{SQL: "DEFAULT"}
+			}
 		}
-
-		// Get the value for this field
-		fieldValue := db.Statement.ReflectValue.FieldByName(field.Name)
-		if !fieldValue.IsValid() {
-			continue
+	}
+	if len(values.Columns) == 0 && len(values.Values) > 1 && autoIncrementField != nil {
+		values.Columns = []clause.Column{{Name: autoIncrementField.DBName}}
+		for i := range values.Values {
+			values.Values[i] = []interface{}{clause.Expr{SQL: "DEFAULT"}}
 		}
+	}
+	return values
+}
 
-		// For optional fields, skip zero values
-		if field.HasDefaultValue && fieldValue.Kind() != reflect.String && fieldValue.IsZero() {
-			continue
+// createRecords returns the structs being created: the model itself, or each
+// element of a slice or array of models. Other values (e.g. maps) yield none.
+func createRecords(stmt *gorm.Statement) []reflect.Value {
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Struct:
+		return []reflect.Value{stmt.ReflectValue}
+	case reflect.Slice, reflect.Array:
+		records := make([]reflect.Value, 0, stmt.ReflectValue.Len())
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			record := reflect.Indirect(stmt.ReflectValue.Index(i))
+			if record.Kind() != reflect.Struct {
+				return nil
+			}
+			records = append(records, record)
 		}
-
-		fields = append(fields, db.Statement.Quote(field.DBName))
-		placeholders = append(placeholders, "?")
-		values = append(values, fieldValue.Interface())
+		return records
 	}
+	return nil
+}
 
-	if len(fields) == 0 {
-		return "", nil
+// setAutoIncrementID stores a generated ID in a created record
+func setAutoIncrementID(record reflect.Value, autoIncrementField *schema.Field, id int64) {
+	if !record.CanAddr() {
+		return
+	}
+	idField := record.FieldByName(autoIncrementField.Name)
+	if !idField.IsValid() || !idField.CanSet() {
+		return
+	}
+	// Handle different integer types
+	switch idField.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if id >= 0 {
+			idField.SetUint(uint64(id))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		idField.SetInt(id)
 	}
-
-	tableName := db.Statement.Quote(db.Statement.Table)
-	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) RETURNING %s",
-		tableName,
-		strings.Join(fields, ", "),
-		strings.Join(placeholders, ", "),
-		db.Statement.Quote(autoIncrementField.DBName))
-
-	return sql, values
 }
 
 // shouldApplyRowCallbackFix determines if we need to apply our RowQuery callback workaround
@@ -944,12 +1709,21 @@ func isRowCallbackBroken(db *gorm.DB) bool {
 	return isBroken
 }
 
+// queryClauses is the clause order used to build SELECT statements. WINDOW and
+// QUALIFY follow GROUP BY (which renders HAVING) as DuckDB requires.
+var queryClauses = []string{"SELECT", "FROM", "WHERE", "GROUP BY", "WINDOW", "QUALIFY", "ORDER BY", "LIMIT", "FOR"}
+
 // queryCallback replaces GORM's default query callback with a DuckDB-compatible version
 func queryCallback(db *gorm.DB) {
 	if db.Error != nil {
 		return
 	}
 
+	// Set default build clauses if not set, before building so DryRun/ToSQL see the SQL too
+	if len(db.Statement.BuildClauses) == 0 {
+		db.Statement.BuildClauses = queryClauses
+	}
+
 	// Use GORM's default query building logic
 	callbacks.BuildQuerySQL(db)
 
@@ -958,11 +1732,6 @@ func queryCallback(db *gorm.DB) {
 		return
 	}
 
-	// Set default build clauses if not set
-	if len(db.Statement.BuildClauses) == 0 {
-		db.Statement.BuildClauses = []string{"SELECT", "FROM", "WHERE", "GROUP BY", "ORDER BY", "LIMIT", "FOR"}
-	}
-
 	// Check if SQL was built
 	if db.Statement.SQL.Len() == 0 {
 		db.Statement.Build(db.Statement.BuildClauses...)
@@ -1019,6 +1788,16 @@ func rowQueryCallback(db *gorm.DB) {
 		return
 	}
 
+	// Build the SELECT for chained queries (Table/Select/Where ... Rows()); Raw
+	// statements already carry their SQL and are left untouched.
+	if len(db.Statement.BuildClauses) == 0 {
+		db.Statement.BuildClauses = queryClauses
+	}
+	callbacks.BuildQuerySQL(db)
+	if db.Error != nil {
+		return
+	}
+
 	// Only process if we have SQL to execute
 	if db.Statement.SQL.Len() == 0 {
 		return
@@ -1060,9 +1839,22 @@ func translateDriverError(err error) error {
 	if err == nil {
 		return nil
 	}
+	if readOnlyViolation(err) {
+		return fmt.Errorf("duckdb driver error: %w: %w", ErrReadOnly, err)
+	}
 	return fmt.Errorf("duckdb driver error: %w", err)
 }
 
+// readOnlyViolation reports whether err is DuckDB refusing a write to a
+// database attached read-only. DuckDB raises it as an invalid input error,
+// `Cannot execute statement of type "INSERT" on database "d" which is
+// attached in read-only mode!`, a type shared with unrelated errors.
+func readOnlyViolation(err error) bool {
+	var duckErr *duckdb.Error
+	return errors.As(err, &duckErr) && duckErr.Type == duckdb.ErrorTypeInvalidInput &&
+		strings.Contains(duckErr.Msg, "attached in read-only mode")
+}
+
 // emptyResult implements driver.Result for empty queries
 type emptyResult struct{}
 
@@ -1080,6 +1872,20 @@ func updateCallback(db *gorm.DB) {
 		return
 	}
 
+	// Expand UpdateColumns into one assignment per matching column
+	if update, ok := db.Statement.Dest.(ColumnsUpdate); ok && db.Statement.Schema != nil {
+		values, err := update.assignments(db.Statement.Schema)
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		db.Statement.Dest = values
+		db.Statement.ReflectValue = reflect.Indirect(reflect.ValueOf(db.Statement.Model))
+	}
+
+	// Keep the caller's conditions; only the assignments are rebuilt below
+	where, hasWhere := db.Statement.Clauses["WHERE"]
+
 	// Use GORM's default update logic
 	callbacks.Update(&callbacks.Config{
 		UpdateClauses: []string{"UPDATE", "SET", "WHERE"},
@@ -1098,6 +1904,9 @@ func updateCallback(db *gorm.DB) {
 		delete(db.Statement.Clauses, "UPDATE")
 		delete(db.Statement.Clauses, "SET")
 		delete(db.Statement.Clauses, "WHERE")
+		if hasWhere {
+			db.Statement.Clauses["WHERE"] = where
+		}
 
 		// Build the update clauses
 		db.Statement.AddClauseIfNotExists(clause.Update{})
@@ -1161,6 +1970,9 @@ func deleteCallback(db *gorm.DB) {
 		return
 	}
 
+	// Keep the caller's conditions; only the DELETE and FROM clauses are rebuilt below
+	where, hasWhere := db.Statement.Clauses["WHERE"]
+
 	// Use GORM's default delete logic
 	callbacks.Delete(&callbacks.Config{
 		DeleteClauses: []string{"DELETE", "FROM", "WHERE"},
@@ -1179,6 +1991,9 @@ func deleteCallback(db *gorm.DB) {
 		delete(db.Statement.Clauses, "DELETE")
 		delete(db.Statement.Clauses, "FROM")
 		delete(db.Statement.Clauses, "WHERE")
+		if hasWhere {
+			db.Statement.Clauses["WHERE"] = where
+		}
 
 		// Build the delete clauses
 		db.Statement.AddClauseIfNotExists(clause.Delete{})
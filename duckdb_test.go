@@ -1,12 +1,20 @@
 package duckdb_test
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
+	goduckdb "github.com/marcboeker/go-duckdb/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 
 	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
@@ -54,6 +62,520 @@ func TestConnection(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestConnector(t *testing.T) {
+	connector, err := goduckdb.NewConnector("", func(execer driver.ExecerContext) error {
+		// Bootstrap queries run on every new connection
+		_, err := execer.ExecContext(context.Background(), "CREATE MACRO IF NOT EXISTS add_tax(x) AS x * 1.25", nil)
+		return err
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = connector.Close() })
+
+	db, err := gorm.Open(duckdb.New(duckdb.Config{Connector: connector}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	var total float64
+	require.NoError(t, db.Raw("SELECT add_tax(?)", 8).Scan(&total).Error)
+	assert.InDelta(t, 10.0, total, 1e-9)
+
+	// Models work as with a DSN, including time conversion by the wrapping driver
+	require.NoError(t, db.AutoMigrate(&User{}))
+	user := User{Name: "Con", Email: "con@example.com", Birthday: time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, db.Create(&user).Error)
+
+	var found User
+	require.NoError(t, db.First(&found, user.ID).Error)
+	assert.Equal(t, "Con", found.Name)
+}
+
+func TestSearchPath(t *testing.T) {
+	db, err := gorm.Open(duckdb.New(duckdb.Config{SearchPath: "staging"}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(&User{}))
+	var schema string
+	require.NoError(t, db.Raw("SELECT table_schema FROM information_schema.tables WHERE table_name = 'users'").Scan(&schema).Error)
+	assert.Equal(t, "staging", schema)
+
+	require.NoError(t, db.Create(&User{Name: "Sam", Email: "sam@example.com"}).Error)
+	var count int64
+	require.NoError(t, db.Raw("SELECT COUNT(*) FROM staging.users").Scan(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	// Switching the path at runtime sends new tables to the other schema
+	require.NoError(t, duckdb.SetSearchPath(db, "archive", "staging"))
+	require.NoError(t, db.Exec("CREATE TABLE snapshots (id INTEGER)").Error)
+	require.NoError(t, db.Raw("SELECT table_schema FROM information_schema.tables WHERE table_name = 'snapshots'").Scan(&schema).Error)
+	assert.Equal(t, "archive", schema)
+	require.NoError(t, db.Raw("SELECT COUNT(*) FROM users").Scan(&count).Error, "staging stays on the path")
+	assert.Equal(t, int64(1), count)
+
+	assert.Error(t, duckdb.SetSearchPath(db))
+}
+
+func TestSearchPath_Pool(t *testing.T) {
+	db, err := gorm.Open(duckdb.New(duckdb.Config{SearchPath: "staging", MaxOpenConns: 3}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+
+	// Hold every connection at once so each one is checked
+	ctx := context.Background()
+	var conns []*sql.Conn
+	for i := 0; i < 3; i++ {
+		conn, err := sqlDB.Conn(ctx)
+		require.NoError(t, err)
+		conns = append(conns, conn)
+		var schema string
+		require.NoError(t, conn.QueryRowContext(ctx, "SELECT current_schema()").Scan(&schema))
+		assert.Equal(t, "staging", schema, "connection %d", i)
+	}
+	for _, conn := range conns {
+		require.NoError(t, conn.Close())
+	}
+
+	err = duckdb.SetSearchPath(db, "archive")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Config.SearchPath")
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		if err := duckdb.SetSearchPath(tx, "archive"); err != nil {
+			return err
+		}
+		var schema string
+		require.NoError(t, tx.Raw("SELECT current_schema()").Scan(&schema).Error)
+		assert.Equal(t, "archive", schema)
+		return nil
+	}))
+
+	_, err = gorm.Open(duckdb.New(duckdb.Config{Conn: sqlDB, SearchPath: "staging"}), &gorm.Config{})
+	assert.Error(t, err, "a shared pool would only get the path on one connection")
+}
+
+func TestDefaultTimeType(t *testing.T) {
+	type Shipment struct {
+		ID          uint `gorm:"primaryKey"`
+		ShippedAt   time.Time
+		DeliveredAt time.Time `gorm:"type:TIMESTAMP"`
+	}
+
+	columnTypes := func(db *gorm.DB) map[string]string {
+		var rows []struct {
+			ColumnName string
+			DataType   string
+		}
+		require.NoError(t, db.Raw("SELECT column_name, data_type FROM information_schema.columns WHERE table_name = 'shipments'").Scan(&rows).Error)
+		types := map[string]string{}
+		for _, row := range rows {
+			types[row.ColumnName] = row.DataType
+		}
+		return types
+	}
+
+	plain, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, plain.AutoMigrate(&Shipment{}))
+	assert.Equal(t, "TIMESTAMP", columnTypes(plain)["shipped_at"])
+
+	db, err := gorm.Open(duckdb.New(duckdb.Config{DefaultTimeType: "TIMESTAMPTZ"}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Shipment{}))
+	require.NoError(t, db.AutoMigrate(&Shipment{}), "migrating again leaves the column alone")
+	types := columnTypes(db)
+	assert.Equal(t, "TIMESTAMP WITH TIME ZONE", types["shipped_at"])
+	assert.Equal(t, "TIMESTAMP", types["delivered_at"], "a type tag overrides the default")
+
+	// The stored instant is independent of the session time zone
+	shippedAt := time.Date(2024, 3, 1, 10, 30, 0, 0, time.FixedZone("UTC+5", 5*3600))
+	shipment := Shipment{ShippedAt: shippedAt, DeliveredAt: shippedAt}
+	require.NoError(t, db.Create(&shipment).Error)
+	require.NoError(t, db.Exec("SET TimeZone = 'America/New_York'").Error)
+
+	var epoch int64
+	require.NoError(t, db.Raw("SELECT CAST(epoch(shipped_at) AS BIGINT) FROM shipments").Scan(&epoch).Error)
+	assert.Equal(t, shippedAt.Unix(), epoch)
+
+	var found Shipment
+	require.NoError(t, db.First(&found, shipment.ID).Error)
+	assert.True(t, found.ShippedAt.Equal(shippedAt), "got %v", found.ShippedAt)
+
+	_, err = gorm.Open(duckdb.New(duckdb.Config{DefaultTimeType: "DATETIME"}), &gorm.Config{})
+	assert.Error(t, err)
+}
+
+func TestDefaultTimeType_ZonedRoundTrip(t *testing.T) {
+	type Appointment struct {
+		ID       uint `gorm:"primaryKey"`
+		StartsAt time.Time
+		EndsAt   *time.Time
+	}
+
+	db, err := gorm.Open(duckdb.New(duckdb.Config{DefaultTimeType: "TIMESTAMPTZ"}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Appointment{}))
+
+	var rows []struct {
+		ColumnName string
+		DataType   string
+	}
+	require.NoError(t, db.Raw("SELECT column_name, data_type FROM information_schema.columns WHERE table_name = 'appointments'").Scan(&rows).Error)
+	for _, row := range rows {
+		if row.ColumnName != "id" {
+			assert.Equal(t, "TIMESTAMP WITH TIME ZONE", row.DataType, row.ColumnName)
+		}
+	}
+
+	tokyo := time.FixedZone("JST", 9*3600)
+	startsAt := time.Date(2024, 11, 3, 9, 15, 30, 123456000, tokyo)
+	endsAt := startsAt.Add(90 * time.Minute)
+	appointment := Appointment{StartsAt: startsAt, EndsAt: &endsAt}
+	require.NoError(t, db.Create(&appointment).Error)
+
+	var found Appointment
+	require.NoError(t, db.First(&found, appointment.ID).Error)
+	assert.True(t, found.StartsAt.Equal(startsAt), "got %v", found.StartsAt)
+	require.NotNil(t, found.EndsAt)
+	assert.True(t, found.EndsAt.Equal(endsAt), "got %v", found.EndsAt)
+	assert.Equal(t, "09:15:30", found.StartsAt.In(tokyo).Format("15:04:05"))
+}
+
+func TestConnectionPoolConfig(t *testing.T) {
+	open := func(config duckdb.Config) *sql.DB {
+		db, err := gorm.Open(duckdb.New(config), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = sqlDB.Close() })
+		return sqlDB
+	}
+
+	assert.Equal(t, 1, open(duckdb.Config{DSN: ":memory:"}).Stats().MaxOpenConnections, "a single connection by default")
+
+	sqlDB := open(duckdb.Config{DSN: ":memory:", MaxOpenConns: 4, ConnMaxLifetime: time.Minute})
+	assert.Equal(t, 4, sqlDB.Stats().MaxOpenConnections)
+
+	// Connections share the database, so readers on several of them see the same tables
+	ctx := context.Background()
+	_, err := sqlDB.ExecContext(ctx, "CREATE TABLE readings AS SELECT range AS id FROM range(100)")
+	require.NoError(t, err)
+	conns := make([]*sql.Conn, 3)
+	for i := range conns {
+		conns[i], err = sqlDB.Conn(ctx)
+		require.NoError(t, err)
+		var count int
+		require.NoError(t, conns[i].QueryRowContext(ctx, "SELECT COUNT(*) FROM readings").Scan(&count))
+		assert.Equal(t, 100, count)
+	}
+	assert.Equal(t, 3, sqlDB.Stats().InUse)
+	for _, conn := range conns {
+		require.NoError(t, conn.Close())
+	}
+	assert.Equal(t, 3, sqlDB.Stats().Idle, "idle connections default to the pool size")
+}
+
+func TestOpenSettings(t *testing.T) {
+	setting := func(db *gorm.DB, name string) string {
+		var value string
+		require.NoError(t, db.Raw("SELECT current_setting(?)", name).Scan(&value).Error)
+		return value
+	}
+	open := func(config duckdb.Config) *gorm.DB {
+		db, err := gorm.Open(duckdb.New(config), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+		return db
+	}
+
+	db := open(duckdb.Config{DSN: ":memory:?threads=2&memory_limit=1GiB"})
+	assert.Equal(t, "2", setting(db, "threads"))
+	assert.Equal(t, "1.0 GiB", setting(db, "memory_limit"))
+
+	db = open(duckdb.Config{
+		DSN:          ":memory:?threads=3",
+		Settings:     map[string]string{"threads": "5", "memory_limit": "512MB"},
+		BootQueries:  []string{"SET TimeZone = 'America/Denver'", "CREATE TEMP TABLE boot_marker AS SELECT 1 AS id"},
+		MaxOpenConns: 2,
+	})
+	assert.Equal(t, "3", setting(db, "threads"), "the DSN takes precedence")
+	assert.Equal(t, "488.2 MiB", setting(db, "memory_limit"))
+
+	// Boot queries run on every connection of the pool
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	ctx := context.Background()
+	conns := make([]*sql.Conn, 2)
+	for i := range conns {
+		conns[i], err = sqlDB.Conn(ctx)
+		require.NoError(t, err)
+		var zone string
+		require.NoError(t, conns[i].QueryRowContext(ctx, "SELECT current_setting('TimeZone')").Scan(&zone))
+		assert.Equal(t, "America/Denver", zone)
+		var marker int
+		require.NoError(t, conns[i].QueryRowContext(ctx, "SELECT id FROM boot_marker").Scan(&marker))
+	}
+	for _, conn := range conns {
+		require.NoError(t, conn.Close())
+	}
+
+	_, err = gorm.Open(duckdb.New(duckdb.Config{DSN: ":memory:", BootQueries: []string{"SELEC 1"}}), &gorm.Config{})
+	assert.Error(t, err)
+	_, err = gorm.Open(duckdb.New(duckdb.Config{DSN: ":memory:", Settings: map[string]string{"no_such_option": "1"}}), &gorm.Config{})
+	assert.Error(t, err)
+}
+
+type ReadOnlyNote struct {
+	ID   uint `gorm:"primaryKey"`
+	Body string
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.duckdb")
+	db, err := gorm.Open(duckdb.Open(path), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&ReadOnlyNote{}))
+	require.NoError(t, db.Create(&ReadOnlyNote{Body: "kept"}).Error)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	db, err = gorm.Open(duckdb.OpenReadOnly(path), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	defer func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	}()
+
+	var notes []ReadOnlyNote
+	require.NoError(t, db.Find(&notes).Error)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "kept", notes[0].Body)
+
+	// Existing tables pass migration, missing ones cannot be created
+	require.NoError(t, db.AutoMigrate(&ReadOnlyNote{}))
+	err = db.AutoMigrate(&User{})
+	assert.ErrorIs(t, err, duckdb.ErrReadOnly)
+
+	err = db.Create(&ReadOnlyNote{Body: "rejected"}).Error
+	assert.ErrorIs(t, err, duckdb.ErrReadOnly)
+	err = db.Model(&ReadOnlyNote{}).Where("id = ?", notes[0].ID).Update("body", "changed").Error
+	assert.ErrorIs(t, err, duckdb.ErrReadOnly)
+
+	_, err = gorm.Open(duckdb.New(duckdb.Config{Conn: sqlDB, ReadOnly: true}), &gorm.Config{})
+	assert.Error(t, err)
+
+	// A search path is set without creating schemas, so it must name existing ones
+	pathDB, err := gorm.Open(duckdb.New(duckdb.Config{DSN: path, ReadOnly: true, SearchPath: "main"}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, pathDB.Find(&notes).Error)
+	assert.Len(t, notes, 1)
+	if sqlDB, err := pathDB.DB(); err == nil {
+		_ = sqlDB.Close()
+	}
+	_, err = gorm.Open(duckdb.New(duckdb.Config{DSN: path, ReadOnly: true, SearchPath: "staging"}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, duckdb.ErrReadOnly)
+}
+
+func TestAllSettings(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Exec("SET threads = 3").Error)
+
+	settings, err := duckdb.AllSettings(db)
+	require.NoError(t, err)
+
+	byName := map[string]duckdb.Setting{}
+	for _, setting := range settings {
+		byName[setting.Name] = setting
+	}
+	require.Contains(t, byName, "threads")
+	assert.Equal(t, "3", byName["threads"].Value)
+	assert.NotEmpty(t, byName["threads"].Description)
+	assert.NotEmpty(t, byName["threads"].Scope)
+	require.Contains(t, byName, "memory_limit")
+	assert.NotEmpty(t, byName["memory_limit"].Value)
+}
+
+func TestSequenceValues(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Exec("CREATE SEQUENCE order_ids START 100").Error)
+
+	first, err := duckdb.NextVal(db, "order_ids")
+	require.NoError(t, err)
+	second, err := duckdb.NextVal(db, "order_ids")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), first)
+	assert.Equal(t, first+1, second)
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		next, err := duckdb.NextVal(tx, "main.order_ids")
+		require.NoError(t, err)
+		current, err := duckdb.CurrVal(tx, "order_ids")
+		require.NoError(t, err)
+		assert.Equal(t, next, current)
+		return nil
+	}))
+
+	_, err = duckdb.NextVal(db, "order_ids'); DROP TABLE users; --")
+	assert.Error(t, err)
+	_, err = duckdb.NextVal(db, "missing_seq")
+	assert.Error(t, err)
+}
+
+func TestVersionAndFeatures(t *testing.T) {
+	db := setupTestDB(t)
+
+	version, err := duckdb.Version(db)
+	require.NoError(t, err)
+	assert.Regexp(t, `^v\d+\.\d+\.\d+`, version)
+
+	assert.True(t, duckdb.SupportsFeature(db, duckdb.FeatureAddPrimaryKey))
+	assert.True(t, duckdb.SupportsFeature(db, duckdb.FeatureReadOnlyTransactions))
+	assert.True(t, duckdb.SupportsFeature(db, duckdb.FeatureJSON))
+	assert.True(t, duckdb.SupportsFeature(db, "list_zip"))
+	assert.False(t, duckdb.SupportsFeature(db, "no_such_function"))
+
+	// Probes leave nothing behind and the connection stays writable
+	var probes int64
+	require.NoError(t, db.Raw("SELECT COUNT(*) FROM duckdb_tables() WHERE table_name = 'gorm_duckdb_probe'").Scan(&probes).Error)
+	assert.Zero(t, probes)
+	require.NoError(t, db.Create(&User{Name: "Vic", Email: "vic@example.com"}).Error)
+}
+
+func TestDataDictionary(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Exec("CREATE VIEW adult_users AS SELECT * FROM users WHERE age >= 18").Error)
+	require.NoError(t, db.Exec("COMMENT ON TABLE users IS 'Registered accounts'").Error)
+	require.NoError(t, db.Exec("COMMENT ON COLUMN users.email IS 'Login address, unique'").Error)
+
+	entries, err := duckdb.DataDictionary(db)
+	require.NoError(t, err)
+
+	var columns []string
+	for _, entry := range entries {
+		assert.Equal(t, "users", entry.Table, "views are not part of the dictionary")
+		assert.Equal(t, "main", entry.Schema)
+		assert.Equal(t, "Registered accounts", entry.TableComment)
+		columns = append(columns, entry.Column)
+	}
+	assert.Equal(t, []string{"id", "name", "email", "age", "birthday", "created_at", "updated_at"}, columns)
+
+	email := entries[2]
+	assert.Equal(t, "VARCHAR", email.Type)
+	assert.Equal(t, "Login address, unique", email.Comment)
+	assert.Empty(t, entries[1].Comment)
+}
+
+type attachedCustomer struct {
+	ID   uint `gorm:"primaryKey;autoIncrement:false"`
+	Name string
+}
+
+func (attachedCustomer) TableName() string    { return "customers" }
+func (attachedCustomer) DatabaseName() string { return "crm" }
+
+func TestCrossDatabaseJoins(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Exec("ATTACH ':memory:' AS crm").Error)
+	require.NoError(t, db.Exec("CREATE TABLE crm.customers (id INTEGER PRIMARY KEY, name VARCHAR)").Error)
+
+	type Order struct {
+		ID         uint `gorm:"primaryKey"`
+		CustomerID uint
+		Total      float64
+	}
+	require.NoError(t, db.AutoMigrate(&Order{}))
+
+	for _, customer := range []attachedCustomer{{ID: 1, Name: "Ada"}, {ID: 2, Name: "Grace"}, {ID: 3, Name: "Linus"}} {
+		require.NoError(t, db.Create(&customer).Error)
+	}
+	for _, order := range []Order{{ID: 1, CustomerID: 1, Total: 12.5}, {ID: 2, CustomerID: 2, Total: 40}, {ID: 3, CustomerID: 1, Total: 7}} {
+		require.NoError(t, db.Create(&order).Error)
+	}
+
+	var count int64
+	require.NoError(t, db.Raw("SELECT count(*) FROM crm.customers").Scan(&count).Error)
+	assert.Equal(t, int64(3), count, "the model's rows are written to the attached database")
+
+	// The attached-database model on the FROM side
+	var big []attachedCustomer
+	require.NoError(t, db.Joins("JOIN orders ON orders.customer_id = customers.id").
+		Where("orders.total > ?", 10).Order("customers.id").Find(&big).Error)
+	require.Len(t, big, 2)
+	assert.Equal(t, "Ada", big[0].Name)
+	assert.Equal(t, "Grace", big[1].Name)
+
+	var found attachedCustomer
+	require.NoError(t, db.First(&found, 3).Error)
+	assert.Equal(t, "Linus", found.Name)
+
+	// A main-database model joined against the attached table
+	type orderLine struct {
+		Name  string
+		Total float64
+	}
+	var lines []orderLine
+	require.NoError(t, db.Model(&Order{}).Select("customers.name, orders.total").
+		Joins("JOIN crm.customers ON customers.id = orders.customer_id").
+		Order("orders.id").Scan(&lines).Error)
+	assert.Equal(t, []orderLine{{"Ada", 12.5}, {"Grace", 40}, {"Ada", 7}}, lines)
+
+	// Per-query database selection
+	var names []string
+	require.NoError(t, db.Clauses(duckdb.UseDatabase("crm")).Table("customers").Order("id").Pluck("name", &names).Error)
+	assert.Equal(t, []string{"Ada", "Grace", "Linus"}, names)
+
+	require.NoError(t, db.Model(&attachedCustomer{}).Where("id = ?", 3).Update("name", "Torvalds").Error)
+	require.NoError(t, db.Delete(&attachedCustomer{}, 2).Error)
+	require.NoError(t, db.Clauses(duckdb.UseDatabase("crm")).Table("customers").Order("id").Pluck("name", &names).Error)
+	assert.Equal(t, []string{"Ada", "Torvalds"}, names)
+}
+
+type AttachedEvent struct {
+	ID   uint `gorm:"primaryKey"`
+	Kind string
+}
+
+func TestAttachDetach(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, duckdb.Attach(db, ":memory:", "analytics", false))
+
+	require.NoError(t, db.Exec("CREATE TABLE analytics.main.attached_events (id INTEGER PRIMARY KEY, kind VARCHAR)").Error)
+
+	require.NoError(t, db.Table("analytics.main.attached_events").Create(&AttachedEvent{ID: 1, Kind: "click"}).Error)
+	require.NoError(t, db.Table("analytics.main.attached_events").Create(&AttachedEvent{ID: 2, Kind: "view"}).Error)
+
+	var kinds []string
+	require.NoError(t, db.Table("analytics.main.attached_events").Order("id").Pluck("kind", &kinds).Error)
+	assert.Equal(t, []string{"click", "view"}, kinds)
+
+	var local int64
+	require.NoError(t, db.Raw("SELECT count(*) FROM information_schema.tables WHERE table_name = 'attached_events' AND table_catalog = current_database()").Scan(&local).Error)
+	assert.Zero(t, local, "the table lives in the attached database")
+
+	require.NoError(t, duckdb.Detach(db, "analytics"))
+	assert.Error(t, db.Table("analytics.main.attached_events").Pluck("kind", &kinds).Error)
+	assert.Error(t, duckdb.Detach(db, "analytics"))
+
+	// Read-only attachments reject writes
+	path := filepath.Join(t.TempDir(), "archive.duckdb")
+	require.NoError(t, duckdb.Attach(db, path, "archive", false))
+	require.NoError(t, db.Exec("CREATE TABLE archive.entries (id INTEGER)").Error)
+	require.NoError(t, duckdb.Detach(db, "archive"))
+	require.NoError(t, duckdb.Attach(db, path, "archive", true))
+	assert.ErrorIs(t, db.Exec("INSERT INTO archive.entries VALUES (1)").Error, duckdb.ErrReadOnly)
+	require.NoError(t, duckdb.Detach(db, "archive"))
+}
+
 func TestBasicCRUD(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -95,6 +617,144 @@ func TestBasicCRUD(t *testing.T) {
 	assert.Equal(t, gorm.ErrRecordNotFound, err)
 }
 
+func TestCreateDefaultValues(t *testing.T) {
+	db := setupTestDB(t)
+
+	// A model whose only column is the auto-increment primary key
+	type Ticket struct {
+		ID uint `gorm:"primaryKey;autoIncrement"`
+	}
+	require.NoError(t, db.AutoMigrate(&Ticket{}))
+
+	first := Ticket{}
+	require.NoError(t, db.Create(&first).Error)
+	assert.NotZero(t, first.ID)
+
+	second := Ticket{}
+	require.NoError(t, db.Create(&second).Error)
+	assert.Greater(t, second.ID, first.ID)
+
+	var count int64
+	require.NoError(t, db.Model(&Ticket{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestDeleteByPrimaryKey(t *testing.T) {
+	db := setupTestDB(t)
+
+	users := []User{
+		{Name: "Keep One", Email: "keep1@example.com", Age: 20},
+		{Name: "Drop", Email: "drop@example.com", Age: 30},
+		{Name: "Keep Two", Email: "keep2@example.com", Age: 40},
+	}
+	for i := range users {
+		require.NoError(t, db.Create(&users[i]).Error)
+	}
+
+	result := db.Delete(&User{}, users[1].ID)
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(1), result.RowsAffected)
+
+	var remaining []User
+	require.NoError(t, db.Order("id").Find(&remaining).Error)
+	require.Len(t, remaining, 2)
+	assert.Equal(t, "Keep One", remaining[0].Name)
+	assert.Equal(t, "Keep Two", remaining[1].Name)
+}
+
+func TestCreateBatch(t *testing.T) {
+	db := setupTestDB(t)
+
+	users := make([]User, 100)
+	for i := range users {
+		users[i] = User{Name: fmt.Sprintf("user%03d", i), Email: fmt.Sprintf("user%03d@example.com", i), Age: uint8(i)}
+	}
+	result := db.Create(&users)
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(100), result.RowsAffected)
+
+	seen := map[uint]bool{}
+	for i, user := range users {
+		require.NotZero(t, user.ID, "user %d has no ID", i)
+		assert.False(t, seen[user.ID], "duplicate ID %d", user.ID)
+		seen[user.ID] = true
+
+		var stored User
+		require.NoError(t, db.First(&stored, user.ID).Error)
+		assert.Equal(t, user.Name, stored.Name)
+	}
+
+	// Slices of pointers work too, and single-column models insert DEFAULT rows
+	pointers := []*User{{Name: "p1", Email: "p1@example.com"}, {Name: "p2", Email: "p2@example.com"}}
+	require.NoError(t, db.Create(&pointers).Error)
+	assert.Equal(t, pointers[0].ID+1, pointers[1].ID)
+
+	type Ticket struct {
+		ID uint `gorm:"primaryKey;autoIncrement"`
+	}
+	require.NoError(t, db.AutoMigrate(&Ticket{}))
+	tickets := make([]Ticket, 3)
+	require.NoError(t, db.Create(&tickets).Error)
+	assert.Equal(t, []uint{1, 2, 3}, []uint{tickets[0].ID, tickets[1].ID, tickets[2].ID})
+}
+
+func TestCreateBatch_DefaultColumns(t *testing.T) {
+	db := setupTestDB(t)
+
+	type Job struct {
+		ID       uint `gorm:"primaryKey"`
+		Name     string
+		Priority int `gorm:"default:5"`
+	}
+	require.NoError(t, db.AutoMigrate(&Job{}))
+	require.NoError(t, db.Exec("ALTER TABLE jobs ALTER COLUMN priority SET DEFAULT 5").Error)
+
+	// Priority is written only where it is set; the other rows take the default
+	jobs := []Job{{Name: "a"}, {Name: "b", Priority: 9}, {ID: 50, Name: "c"}}
+	require.NoError(t, db.Create(&jobs).Error)
+	assert.Equal(t, uint(50), jobs[2].ID, "explicit IDs are kept")
+
+	var stored []Job
+	require.NoError(t, db.Order("name").Find(&stored).Error)
+	require.Len(t, stored, 3)
+	assert.Equal(t, []int{5, 9, 5}, []int{stored[0].Priority, stored[1].Priority, stored[2].Priority})
+	assert.Equal(t, jobs[0].ID, stored[0].ID)
+	assert.Equal(t, uint(50), stored[2].ID)
+}
+
+func TestCreateBatch_Clauses(t *testing.T) {
+	db := setupTestDB(t)
+
+	type Gauge struct {
+		ID    uint   `gorm:"primaryKey"`
+		Code  string `gorm:"uniqueIndex"`
+		Level int
+	}
+	require.NoError(t, db.AutoMigrate(&Gauge{}))
+	require.NoError(t, db.Create(&[]Gauge{{Code: "a", Level: 1}, {Code: "b", Level: 2}}).Error)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Create(&[]Gauge{{Code: "x"}, {Code: "y"}})
+	})
+	assert.Contains(t, sql, `VALUES ("x",0),("y",0) RETURNING "id"`)
+
+	onConflict := clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, DoUpdates: clause.AssignmentColumns([]string{"level"})}
+	result := db.Clauses(onConflict).Create(&[]Gauge{{Code: "a", Level: 10}, {Code: "c", Level: 30}})
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(2), result.RowsAffected)
+
+	require.NoError(t, db.Clauses(clause.OnConflict{DoNothing: true}).Create(&[]Gauge{{Code: "b", Level: 99}, {Code: "d", Level: 40}}).Error)
+	require.NoError(t, db.Clauses(clause.Insert{Modifier: "OR IGNORE"}).Create(&[]Gauge{{Code: "c", Level: 99}, {Code: "e", Level: 50}}).Error)
+
+	var gauges []Gauge
+	require.NoError(t, db.Order("code").Find(&gauges).Error)
+	levels := map[string]int{}
+	for _, gauge := range gauges {
+		levels[gauge.Code] = gauge.Level
+	}
+	assert.Equal(t, map[string]int{"a": 10, "b": 2, "c": 30, "d": 40, "e": 50}, levels)
+}
+
 func TestTransaction(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -121,6 +781,166 @@ func TestTransaction(t *testing.T) {
 	assert.Equal(t, int64(2), count)
 }
 
+func TestTransactionRollbackCreate(t *testing.T) {
+	db, err := gorm.Open(duckdb.New(duckdb.Config{DSN: ":memory:", MaxOpenConns: 2}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&User{}))
+
+	rollback := errors.New("rollback")
+	err = db.Transaction(func(tx *gorm.DB) error {
+		user := User{Name: "Ghost", Email: "ghost@example.com"}
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		assert.NotZero(t, user.ID)
+
+		// The row is visible inside the transaction but not to other connections
+		var count int64
+		require.NoError(t, tx.Model(&User{}).Where("id = ?", user.ID).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+		require.NoError(t, db.Model(&User{}).Count(&count).Error)
+		assert.Equal(t, int64(0), count)
+		return rollback
+	})
+	require.ErrorIs(t, err, rollback)
+
+	var count int64
+	require.NoError(t, db.Model(&User{}).Count(&count).Error)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestTransactionWorkaround(t *testing.T) {
+	// The second insert violates the unique email index, which aborts the
+	// DuckDB transaction; the body ignores the error and returns nil
+	ignoreFailure := func(tx *gorm.DB) error {
+		if err := tx.Create(&User{Name: "First", Email: "dup@example.com"}).Error; err != nil {
+			return err
+		}
+		_ = tx.Create(&User{Name: "Second", Email: "dup@example.com"}).Error
+		return nil
+	}
+	open := func(disable *bool) *gorm.DB {
+		db, err := gorm.Open(duckdb.New(duckdb.Config{DSN: ":memory:", DisableTransactionWorkaround: disable}), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&User{}))
+		return db
+	}
+	count := func(db *gorm.DB) int64 {
+		var count int64
+		require.NoError(t, db.Model(&User{}).Count(&count).Error)
+		return count
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		db := open(nil)
+		err := db.Transaction(ignoreFailure)
+		assert.ErrorIs(t, err, duckdb.ErrTransactionAborted)
+		assert.Equal(t, int64(0), count(db))
+
+		// The connection is usable afterwards and clean transactions commit
+		require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+			return tx.Create(&User{Name: "Kept", Email: "kept@example.com"}).Error
+		}))
+		assert.Equal(t, int64(1), count(db))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		disable := true
+		db := open(&disable)
+		// DuckDB reports the commit as successful but keeps nothing
+		require.NoError(t, db.Transaction(ignoreFailure))
+		assert.Equal(t, int64(0), count(db))
+
+		require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+			return tx.Create(&User{Name: "Kept", Email: "kept@example.com"}).Error
+		}))
+		assert.Equal(t, int64(1), count(db))
+	})
+}
+
+func TestTransactionOptions(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Create(&User{Name: "Alice", Email: "alice@example.com"}).Error)
+
+	// Reads succeed in a read-only transaction, writes are rejected
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&User{}).Count(&count).Error; err != nil {
+			return err
+		}
+		assert.Equal(t, int64(1), count)
+		return tx.Create(&User{Name: "Bob", Email: "bob@example.com"}).Error
+	}, &sql.TxOptions{ReadOnly: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read-only")
+
+	// The connection is usable for writes again afterwards
+	require.NoError(t, db.Create(&User{Name: "Carol", Email: "carol@example.com"}).Error)
+
+	// Snapshot isolation is what DuckDB provides, so it is accepted
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&User{Name: "Dan", Email: "dan@example.com"}).Error
+	}, &sql.TxOptions{Isolation: sql.LevelSnapshot}))
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		t.Fatal("transaction body should not run")
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelReadUncommitted})
+	require.ErrorIs(t, err, duckdb.ErrIsolationLevelNotSupported)
+	assert.Contains(t, err.Error(), "Read Uncommitted")
+
+	var count int64
+	require.NoError(t, db.Model(&User{}).Count(&count).Error)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestNestedTransactions(t *testing.T) {
+	db := setupTestDB(t)
+	dialector := db.Dialector.(*duckdb.Dialector)
+
+	err := dialector.SavePoint(db, "sp1; DROP TABLE users")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid savepoint name")
+	require.True(t, db.Migrator().HasTable(&User{}))
+	assert.Error(t, dialector.ReleaseSavePoint(db, "sp-1"))
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&User{Name: "Outer", Email: "outer@example.com"}).Error; err != nil {
+			return err
+		}
+		return tx.Transaction(func(inner *gorm.DB) error {
+			if err := inner.Create(&User{Name: "Inner", Email: "inner@example.com"}).Error; err != nil {
+				return err
+			}
+			return errors.New("roll back the inner transaction")
+		})
+	})
+	assert.ErrorIs(t, err, duckdb.ErrSavePointNotSupported)
+
+	// The nested call fails and the outer transaction is rolled back as a whole
+	var names []string
+	require.NoError(t, db.Model(&User{}).Pluck("name", &names).Error)
+	assert.Empty(t, names)
+
+	// Nested calls can instead join the outer transaction
+	flat := db.Session(&gorm.Session{DisableNestedTransaction: true})
+	require.NoError(t, flat.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&User{Name: "Flat outer", Email: "flat-outer@example.com"}).Error; err != nil {
+			return err
+		}
+		return tx.Transaction(func(inner *gorm.DB) error {
+			return inner.Create(&User{Name: "Flat inner", Email: "flat-inner@example.com"}).Error
+		})
+	}))
+	var count int64
+	require.NoError(t, db.Model(&User{}).Where("name LIKE 'Flat%'").Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
+
 func TestErrorTranslator(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -161,3 +981,91 @@ func TestDataTypes(t *testing.T) {
 	// Check that timestamps are approximately equal (within a second)
 	assert.WithinDuration(t, user.Birthday, retrieved.Birthday, time.Second)
 }
+
+func TestRawExecAndChainedRows(t *testing.T) {
+	db := setupTestDB(t)
+
+	err := db.Exec("INSERT INTO users (id, name, email, age) VALUES (?, ?, ?, ?)", 1, "Exec User", "exec@example.com", 41).Error
+	require.NoError(t, err)
+
+	var count int64
+	require.NoError(t, db.Model(&User{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	rows, err := db.Table("users").Select("name").Where("age > ?", 40).Rows()
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		names = append(names, name)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"Exec User"}, names)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&User{}).Where("age > ?", 40).Find(&[]User{})
+	})
+	assert.Contains(t, sql, "SELECT * FROM \"users\"")
+	assert.Contains(t, sql, "age > 40")
+}
+
+func TestCreateWithoutAutoIncrement(t *testing.T) {
+	db := setupTestDB(t)
+
+	type ManualKey struct {
+		ID   uint `gorm:"primaryKey;autoIncrement:false"`
+		Name string
+	}
+	require.NoError(t, db.AutoMigrate(&ManualKey{}))
+
+	require.NoError(t, db.Create(&ManualKey{ID: 7, Name: "seven"}).Error)
+
+	var got ManualKey
+	require.NoError(t, db.First(&got, 7).Error)
+	assert.Equal(t, "seven", got.Name)
+
+	// DryRun builds the INSERT without executing it
+	stmt := db.Session(&gorm.Session{DryRun: true}).Create(&ManualKey{ID: 8, Name: "eight"}).Statement
+	assert.Contains(t, stmt.SQL.String(), "INSERT INTO")
+
+	var count int64
+	require.NoError(t, db.Model(&ManualKey{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestRawFromFirstQueries(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Create(&User{Name: "Ann", Email: "ann@example.com", Age: 30}).Error)
+	require.NoError(t, db.Create(&User{Name: "Ben", Email: "ben@example.com", Age: 20}).Error)
+
+	// A bare FROM selects every column
+	var users []User
+	require.NoError(t, db.Raw("FROM users ORDER BY id").Scan(&users).Error)
+	require.Len(t, users, 2)
+	assert.Equal(t, "Ann", users[0].Name)
+	assert.Equal(t, "ben@example.com", users[1].Email)
+
+	var names []string
+	require.NoError(t, db.Raw("FROM users SELECT name WHERE age > ? ORDER BY name", 25).Scan(&names).Error)
+	assert.Equal(t, []string{"Ann"}, names)
+
+	var count int64
+	require.NoError(t, db.Raw("FROM users SELECT count(*)").Row().Scan(&count))
+	assert.Equal(t, int64(2), count)
+
+	rows, err := db.Raw("FROM users SELECT name, age ORDER BY age").Rows()
+	require.NoError(t, err)
+	defer rows.Close()
+	var scanned []User
+	for rows.Next() {
+		var user User
+		require.NoError(t, db.ScanRows(rows, &user))
+		scanned = append(scanned, user)
+	}
+	require.Len(t, scanned, 2)
+	assert.Equal(t, "Ben", scanned[0].Name)
+	assert.Equal(t, uint8(30), scanned[1].Age)
+}
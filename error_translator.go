@@ -5,13 +5,16 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/marcboeker/go-duckdb/v2"
 	"gorm.io/gorm"
 )
 
 // ErrorTranslator implements gorm.ErrorTranslator for DuckDB
 type ErrorTranslator struct{}
 
-// Translate converts DuckDB errors to GORM errors
+// Translate converts DuckDB errors to GORM's sentinel errors, so that with
+// gorm.Config{TranslateError: true} callers can check errors.Is(err,
+// gorm.ErrDuplicatedKey). Errors without a GORM equivalent are returned as is.
 func (et ErrorTranslator) Translate(err error) error {
 	if err == nil {
 		return nil
@@ -22,42 +25,37 @@ func (et ErrorTranslator) Translate(err error) error {
 		return gorm.ErrRecordNotFound
 	}
 
-	errStr := err.Error()
-	errStrLower := strings.ToLower(errStr)
+	if translated := constraintViolation(err); translated != nil {
+		return translated
+	}
+
+	// Default to the original error if no specific translation is found
+	return err
+}
+
+// constraintViolation maps a constraint error to the matching GORM error, or
+// returns nil. DuckDB reports violations as constraint errors such as
+// `Duplicate key "id: 1" violates primary key constraint`, `CHECK constraint
+// failed on table t ...` and `Violates foreign key constraint because ...`;
+// the SQLite-style "UNIQUE constraint failed" is recognised too.
+func constraintViolation(err error) error {
+	var duckErr *duckdb.Error
+	if errors.As(err, &duckErr) && duckErr.Type != duckdb.ErrorTypeConstraint {
+		return nil
+	}
 
-	// Handle DuckDB specific errors
+	msg := strings.ToLower(err.Error())
 	switch {
-	case strings.Contains(errStrLower, "unique constraint"):
+	case strings.Contains(msg, "primary key constraint"),
+		strings.Contains(msg, "unique constraint"),
+		strings.Contains(msg, "duplicate key"):
 		return gorm.ErrDuplicatedKey
-	case strings.Contains(errStrLower, "foreign key constraint"):
+	case strings.Contains(msg, "foreign key constraint"):
 		return gorm.ErrForeignKeyViolated
-	case strings.Contains(errStrLower, "check constraint"):
+	case strings.Contains(msg, "check constraint"):
 		return gorm.ErrCheckConstraintViolated
-	case strings.Contains(errStrLower, "not null constraint"):
-		return gorm.ErrInvalidValue
-	case strings.Contains(errStrLower, "no such table"):
-		return gorm.ErrRecordNotFound
-	case strings.Contains(errStrLower, "no such column"):
-		return gorm.ErrInvalidField
-	case strings.Contains(errStrLower, "syntax error"):
-		return gorm.ErrInvalidData
-	case strings.Contains(errStrLower, "connection"):
-		return gorm.ErrInvalidDB
-	case strings.Contains(errStrLower, "database is locked"):
-		return gorm.ErrInvalidDB
-	}
-
-	// Check for specific DuckDB error patterns
-	if strings.Contains(errStrLower, "constraint") {
-		return gorm.ErrInvalidValue
-	}
-
-	if strings.Contains(errStrLower, "invalid") || strings.Contains(errStrLower, "malformed") {
-		return gorm.ErrInvalidData
 	}
-
-	// Default to the original error if no specific translation is found
-	return err
+	return nil
 }
 
 // Common DuckDB error patterns
@@ -86,12 +84,12 @@ func IsSpecificError(err error, target error) bool {
 
 // IsDuplicateKeyError checks if the error is a duplicate key constraint violation
 func IsDuplicateKeyError(err error) bool {
-	return IsSpecificError(err, ErrUniqueConstraint)
+	return err != nil && (IsSpecificError(err, ErrUniqueConstraint) || errors.Is(constraintViolation(err), gorm.ErrDuplicatedKey))
 }
 
 // IsForeignKeyError checks if the error is a foreign key constraint violation
 func IsForeignKeyError(err error) bool {
-	return IsSpecificError(err, ErrForeignKey)
+	return err != nil && (IsSpecificError(err, ErrForeignKey) || errors.Is(constraintViolation(err), gorm.ErrForeignKeyViolated))
 }
 
 // IsNotNullError checks if the error is a not null constraint violation
@@ -332,3 +332,61 @@ func TestErrorTranslator_RealDatabaseErrors(t *testing.T) {
 	err = db.Select("non_existent_column").First(&TestErrorModel{}).Error
 	assert.Error(t, err)
 }
+
+func TestErrorTranslator_ConstraintViolations(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{TranslateError: true})
+	require.NoError(t, err)
+
+	type ErrorAuthor struct {
+		ID    uint   `gorm:"primaryKey"`
+		Email string `gorm:"uniqueIndex"`
+		Age   int
+	}
+	type ErrorBook struct {
+		ID       uint `gorm:"primaryKey;autoIncrement:false"`
+		AuthorID uint
+	}
+	// The migrator does not emit CHECK or FOREIGN KEY constraints yet
+	require.NoError(t, db.Exec("CREATE SEQUENCE seq_error_authors_id").Error)
+	require.NoError(t, db.Exec(`CREATE TABLE error_authors (
+		id BIGINT PRIMARY KEY DEFAULT nextval('seq_error_authors_id'),
+		email VARCHAR UNIQUE,
+		age BIGINT CHECK (age >= 0)
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE error_books (
+		id BIGINT PRIMARY KEY,
+		author_id BIGINT REFERENCES error_authors(id)
+	)`).Error)
+
+	author := ErrorAuthor{Email: "ada@example.com", Age: 36}
+	require.NoError(t, db.Create(&author).Error)
+
+	err = db.Create(&ErrorAuthor{Email: "ada@example.com"}).Error
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey, "unique index")
+
+	err = db.Create(&ErrorAuthor{ID: author.ID, Email: "other@example.com"}).Error
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey, "primary key")
+
+	err = db.Create(&ErrorAuthor{Email: "young@example.com", Age: -1}).Error
+	assert.ErrorIs(t, err, gorm.ErrCheckConstraintViolated)
+
+	err = db.Create(&ErrorBook{ID: 1, AuthorID: 999}).Error
+	assert.ErrorIs(t, err, gorm.ErrForeignKeyViolated)
+
+	// Other errors are passed through unchanged
+	err = db.Exec("SELECT * FROM missing_table").Error
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing_table")
+
+	// Without TranslateError the driver's message is kept and the helpers match
+	// it, here for a unique index created by the migrator
+	plain, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, plain.AutoMigrate(&ErrorAuthor{}))
+	require.NoError(t, plain.Create(&ErrorAuthor{Email: "ada@example.com"}).Error)
+	err = plain.Create(&ErrorAuthor{Email: "ada@example.com"}).Error
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "violates unique constraint")
+	assert.True(t, duckdb.IsDuplicateKeyError(err))
+	assert.ErrorIs(t, duckdb.ErrorTranslator{}.Translate(err), gorm.ErrDuplicatedKey)
+}
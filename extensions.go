@@ -3,8 +3,14 @@ package duckdb
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"gorm.io/gorm"
@@ -31,17 +37,34 @@ type ExtensionConfig struct {
 	// Timeout for extension operations (0 = no timeout)
 	Timeout time.Duration
 
-	// RepositoryURL custom extension repository URL
+	// RepositoryURL custom extension repository URL, local directory or named
+	// repository (e.g. "community") to install extensions from
 	RepositoryURL string
 
-	// AllowUnsigned allows loading unsigned extensions (security risk)
+	// AllowUnsigned allows loading unsigned extensions (security risk). DuckDB
+	// only accepts it when the database opens, which OpenWithExtensions and
+	// NewWithExtensions arrange through the DSN.
 	AllowUnsigned bool
+
+	// AutoLoadKnown sets DuckDB's autoload_known_extensions so known extensions
+	// are loaded on first use without an explicit LoadExtension call.
+	// nil leaves the DuckDB default untouched.
+	AutoLoadKnown *bool
+
+	// AutoInstallKnown sets DuckDB's autoinstall_known_extensions so known
+	// extensions are installed on first use. nil leaves the DuckDB default untouched.
+	AutoInstallKnown *bool
 }
 
 // ExtensionManager handles DuckDB extension operations
 type ExtensionManager struct {
 	db     *gorm.DB
 	config *ExtensionConfig
+
+	unsignedAllowed atomic.Bool
+
+	mu          sync.Mutex
+	loadedPaths map[string]string // extension name -> file loaded by LoadExtensionFromPath
 }
 
 // Common DuckDB extensions
@@ -113,7 +136,8 @@ func (m *ExtensionManager) ListExtensions() ([]Extension, error) {
 			extension_name as name,
 			loaded,
 			installed,
-			description
+			description,
+			extension_version
 		FROM duckdb_extensions()
 		ORDER BY extension_name
 	`
@@ -131,15 +155,16 @@ func (m *ExtensionManager) ListExtensions() ([]Extension, error) {
 
 	for rows.Next() {
 		var ext Extension
-		var description sql.NullString
+		var description, version sql.NullString
 
-		if err := rows.Scan(&ext.Name, &ext.Loaded, &ext.Installed, &description); err != nil {
+		if err := rows.Scan(&ext.Name, &ext.Loaded, &ext.Installed, &description, &version); err != nil {
 			return nil, fmt.Errorf("failed to scan extension row: %w", err)
 		}
 
 		if description.Valid {
 			ext.Description = description.String
 		}
+		ext.Version = version.String
 
 		extensions = append(extensions, ext)
 	}
@@ -165,16 +190,17 @@ func (m *ExtensionManager) GetExtension(name string) (*Extension, error) {
 			extension_name as name,
 			loaded,
 			installed,
-			description
+			description,
+			extension_version
 		FROM duckdb_extensions()
 		WHERE extension_name = ?
 	`
 
 	var ext Extension
-	var description sql.NullString
+	var description, version sql.NullString
 
 	err := m.db.WithContext(ctx).Raw(query, name).Row().Scan(
-		&ext.Name, &ext.Loaded, &ext.Installed, &description,
+		&ext.Name, &ext.Loaded, &ext.Installed, &description, &version,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -186,6 +212,7 @@ func (m *ExtensionManager) GetExtension(name string) (*Extension, error) {
 	if description.Valid {
 		ext.Description = description.String
 	}
+	ext.Version = version.String
 
 	return &ext, nil
 }
@@ -204,6 +231,12 @@ func (m *ExtensionManager) LoadExtension(name string) error {
 		return nil // Already loaded
 	}
 
+	if m.config.AllowUnsigned {
+		if err := m.allowUnsigned(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Install extension if auto-install is enabled and extension is not installed
 	if m.config.AutoInstall {
 		ext, err := m.GetExtension(name)
@@ -243,7 +276,7 @@ func (m *ExtensionManager) InstallExtension(name string) error {
 	}
 
 	// Install the extension
-	query := fmt.Sprintf("INSTALL %s", m.quoteName(name))
+	query := m.installSQL("INSTALL", name, "")
 	if err := m.db.WithContext(ctx).Exec(query).Error; err != nil {
 		return fmt.Errorf("failed to install extension '%s': %w", name, err)
 	}
@@ -251,8 +284,157 @@ func (m *ExtensionManager) InstallExtension(name string) error {
 	return nil
 }
 
+// ForceInstallExtension reinstalls an extension with FORCE INSTALL, replacing
+// the installed build with the repository's current one
+func (m *ExtensionManager) ForceInstallExtension(name string) error {
+	ctx, cancel := m.timeoutContext()
+	defer cancel()
+
+	query := m.installSQL("FORCE INSTALL", name, "")
+	if err := m.db.WithContext(ctx).Exec(query).Error; err != nil {
+		return fmt.Errorf("failed to force install extension '%s': %w", name, err)
+	}
+	return nil
+}
+
+// InstallExtensionVersion installs a specific version of an extension with
+// FORCE INSTALL ... VERSION, replacing whichever build is installed. Restart
+// the database to use it if another version is already loaded.
+func (m *ExtensionManager) InstallExtensionVersion(name, version string) error {
+	if strings.TrimSpace(version) == "" {
+		return fmt.Errorf("no version given for extension '%s'", name)
+	}
+	ctx, cancel := m.timeoutContext()
+	defer cancel()
+
+	query := m.installSQL("FORCE INSTALL", name, version)
+	if err := m.db.WithContext(ctx).Exec(query).Error; err != nil {
+		return fmt.Errorf("failed to install extension '%s' version %s: %w", name, version, err)
+	}
+	return nil
+}
+
+// ErrUninstallNotSupported is returned by UninstallExtension when DuckDB has
+// no UNINSTALL statement. Extensions can then only be removed by deleting
+// their file from the extension directory.
+var ErrUninstallNotSupported = errors.New("UNINSTALL is not supported by this DuckDB version")
+
+// UninstallExtension removes an installed extension with UNINSTALL, returning
+// ErrUninstallNotSupported when DuckDB does not have that statement
+func (m *ExtensionManager) UninstallExtension(name string) error {
+	ctx, cancel := m.timeoutContext()
+	defer cancel()
+
+	query := fmt.Sprintf("UNINSTALL %s", m.quoteName(name))
+	if err := m.db.WithContext(ctx).Exec(query).Error; err != nil {
+		if strings.Contains(err.Error(), `syntax error at or near "UNINSTALL"`) {
+			return fmt.Errorf("failed to uninstall extension '%s': %w", name, ErrUninstallNotSupported)
+		}
+		return fmt.Errorf("failed to uninstall extension '%s': %w", name, err)
+	}
+	return nil
+}
+
+// installSQL builds an INSTALL statement, adding the configured repository
+// and the version if given
+func (m *ExtensionManager) installSQL(command, name, version string) string {
+	query := command + " " + m.quoteName(name)
+	if repository := strings.TrimSpace(m.config.RepositoryURL); repository != "" {
+		// Named repositories such as community are identifiers; a quoted
+		// name would be read as a local path
+		if strings.ContainsAny(repository, "/:.\\") {
+			query += " FROM " + quoteLiteral(repository)
+		} else {
+			query += " FROM " + m.quoteName(repository)
+		}
+	}
+	if version != "" {
+		query += " VERSION " + quoteLiteral(version)
+	}
+	return query
+}
+
+// allowUnsigned makes sure unsigned extensions may be loaded, checking once
+// per manager. DuckDB rejects the setting once the database is running, so an
+// error points at opening the database with it instead.
+func (m *ExtensionManager) allowUnsigned(ctx context.Context) error {
+	if m.unsignedAllowed.Load() {
+		return nil
+	}
+	var allowed bool
+	if err := m.db.WithContext(ctx).Raw("SELECT current_setting('allow_unsigned_extensions')").Row().Scan(&allowed); err != nil {
+		return fmt.Errorf("failed to read allow_unsigned_extensions: %w", err)
+	}
+	if !allowed {
+		if err := m.db.WithContext(ctx).Exec("SET allow_unsigned_extensions = true").Error; err != nil {
+			return fmt.Errorf("failed to allow unsigned extensions, open the database with allow_unsigned_extensions=true (see OpenWithExtensions): %w", err)
+		}
+	}
+	m.unsignedAllowed.Store(true)
+	return nil
+}
+
+// timeoutContext returns a context bounded by the configured timeout
+func (m *ExtensionManager) timeoutContext() (context.Context, context.CancelFunc) {
+	if m.config.Timeout > 0 {
+		return context.WithTimeout(context.Background(), m.config.Timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// LoadExtensionFromPath loads an extension from a .duckdb_extension file, for
+// builds that are not in a repository. Such builds are usually unsigned, so
+// AllowUnsigned must be set. The extension is known by the file's base name,
+// e.g. "h3" for /opt/ext/h3.duckdb_extension.
+func (m *ExtensionManager) LoadExtensionFromPath(path string) error {
+	if !m.config.AllowUnsigned {
+		return fmt.Errorf("loading extension file %s requires AllowUnsigned", path)
+	}
+
+	path = filepath.Clean(path)
+	name, ok := strings.CutSuffix(filepath.Base(path), extensionFileSuffix)
+	if !ok || name == "" {
+		return fmt.Errorf("extension file %s does not end in %s", path, extensionFileSuffix)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("extension file %s: %w", path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("extension file %s is not a regular file", path)
+	}
+
+	ctx, cancel := m.timeoutContext()
+	defer cancel()
+
+	if err := m.allowUnsigned(ctx); err != nil {
+		return err
+	}
+	if err := m.db.WithContext(ctx).Exec("LOAD " + quoteLiteral(path)).Error; err != nil {
+		return fmt.Errorf("failed to load extension file %s: %w", path, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.loadedPaths == nil {
+		m.loadedPaths = make(map[string]string)
+	}
+	m.loadedPaths[name] = path
+	return nil
+}
+
+// extensionFileSuffix is the file name suffix of DuckDB extension builds
+const extensionFileSuffix = ".duckdb_extension"
+
 // IsExtensionLoaded checks if an extension is currently loaded
 func (m *ExtensionManager) IsExtensionLoaded(name string) bool {
+	m.mu.Lock()
+	_, fromPath := m.loadedPaths[name]
+	m.mu.Unlock()
+	if fromPath {
+		return true
+	}
+
 	ext, err := m.GetExtension(name)
 	if err != nil {
 		return false
@@ -409,6 +591,11 @@ func OpenWithExtensions(dsn string, extensionConfig *ExtensionConfig) gorm.Diale
 
 // Initialize initializes the dialector with extension support
 func (d *extensionAwareDialector) Initialize(db *gorm.DB) error {
+	// allow_unsigned_extensions can only be set when the database opens
+	if d.extensionConfig != nil && d.extensionConfig.AllowUnsigned && d.Conn == nil && d.Connector == nil {
+		d.DSN = dsnWithOption(d.DSN, "allow_unsigned_extensions", "true")
+	}
+
 	// First initialize the base dialector
 	if err := d.Dialector.Initialize(db); err != nil {
 		return err
@@ -416,6 +603,10 @@ func (d *extensionAwareDialector) Initialize(db *gorm.DB) error {
 
 	// Create extension manager but don't preload yet
 	if d.extensionConfig != nil {
+		if err := applyExtensionSettings(db, d.extensionConfig); err != nil {
+			return err
+		}
+
 		d.manager = NewExtensionManager(db, d.extensionConfig)
 
 		// We'll preload extensions lazily when first accessed
@@ -425,6 +616,46 @@ func (d *extensionAwareDialector) Initialize(db *gorm.DB) error {
 	return nil
 }
 
+// dsnWithOption adds key=value to the query options of a DuckDB DSN unless
+// the key is already there
+func dsnWithOption(dsn, key, value string) string {
+	path, options, hasOptions := strings.Cut(dsn, "?")
+	if hasOptions {
+		for _, option := range strings.Split(options, "&") {
+			if name, _, _ := strings.Cut(option, "="); strings.EqualFold(name, key) {
+				return dsn
+			}
+		}
+		return path + "?" + options + "&" + key + "=" + url.QueryEscape(value)
+	}
+	return path + "?" + key + "=" + url.QueryEscape(value)
+}
+
+// applyExtensionSettings applies the global DuckDB extension settings from the config.
+// It runs during Initialize, before GORM has set up db.Statement, so it executes
+// directly on the connection pool.
+func applyExtensionSettings(db *gorm.DB, config *ExtensionConfig) error {
+	settings := []struct {
+		name  string
+		value *bool
+	}{
+		{"autoinstall_known_extensions", config.AutoInstallKnown},
+		{"autoload_known_extensions", config.AutoLoadKnown},
+	}
+
+	for _, setting := range settings {
+		if setting.value == nil {
+			continue
+		}
+		query := fmt.Sprintf("SET %s = %t", setting.name, *setting.value)
+		if _, err := db.ConnPool.ExecContext(context.Background(), query); err != nil {
+			return fmt.Errorf("failed to set %s: %w", setting.name, err)
+		}
+	}
+
+	return nil
+}
+
 // Extension manager retrieval functions
 
 // GetExtensionManager retrieves the extension manager from a database instance
@@ -1,6 +1,9 @@
 package duckdb_test
 
 import (
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -315,6 +318,45 @@ func TestExtensionAwareDialector_Initialize(t *testing.T) {
 	assert.True(t, manager.IsExtensionLoaded("json"))
 }
 
+func TestExtensionAwareDialector_AutoLoadKnown(t *testing.T) {
+	enabled := true
+	config := &duckdb.ExtensionConfig{
+		AutoLoadKnown:    &enabled,
+		AutoInstallKnown: &enabled,
+	}
+
+	dialector := duckdb.OpenWithExtensions(":memory:", config)
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	require.NoError(t, err)
+
+	var autoload bool
+	err = db.Raw("SELECT current_setting('autoload_known_extensions')").Scan(&autoload).Error
+	require.NoError(t, err)
+	assert.True(t, autoload)
+
+	// JSON functions should work without an explicit LoadExtension call
+	var name string
+	err = db.Raw(`SELECT json_extract_string('{"name": "duck"}', '$.name')`).Scan(&name).Error
+	require.NoError(t, err)
+	assert.Equal(t, "duck", name)
+}
+
+func TestExtensionAwareDialector_AutoLoadKnownDisabled(t *testing.T) {
+	disabled := false
+	config := &duckdb.ExtensionConfig{
+		AutoLoadKnown: &disabled,
+	}
+
+	dialector := duckdb.OpenWithExtensions(":memory:", config)
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	require.NoError(t, err)
+
+	var autoload bool
+	err = db.Raw("SELECT current_setting('autoload_known_extensions')").Scan(&autoload).Error
+	require.NoError(t, err)
+	assert.False(t, autoload)
+}
+
 func TestExtensionAwareDialector_NewWithExtensions(t *testing.T) {
 	t.Skip("Extension-aware dialector has GORM integration issues with InstanceSet")
 }
@@ -470,3 +512,130 @@ func TestExtensionManager_QuoteName(t *testing.T) {
 		_ = err // Will likely error due to extension not existing, but shouldn't crash
 	}
 }
+
+func TestExtensionManager_ForceInstall(t *testing.T) {
+	_, manager := setupBasicExtensionTestDB(t)
+
+	if err := manager.ForceInstallExtension("httpfs"); err != nil {
+		t.Skipf("extension repository unreachable: %v", err)
+	}
+	ext, err := manager.GetExtension("httpfs")
+	require.NoError(t, err)
+	assert.True(t, ext.Installed)
+	require.NotEmpty(t, ext.Version)
+
+	// Reinstalling leaves it installed
+	require.NoError(t, manager.ForceInstallExtension("httpfs"))
+
+	extensions, err := manager.ListExtensions()
+	require.NoError(t, err)
+	for _, listed := range extensions {
+		if listed.Name == "httpfs" {
+			assert.True(t, listed.Installed)
+			assert.Equal(t, ext.Version, listed.Version)
+		}
+	}
+}
+
+func TestExtensionManager_InstallExtensionVersion(t *testing.T) {
+	_, manager := setupBasicExtensionTestDB(t)
+
+	assert.Error(t, manager.InstallExtensionVersion("httpfs", ""))
+
+	ext, err := manager.GetExtension("json")
+	require.NoError(t, err)
+	assert.NotEmpty(t, ext.Version, "built-in extensions report DuckDB's version")
+}
+
+func TestExtensionManager_UninstallExtension(t *testing.T) {
+	_, manager := setupBasicExtensionTestDB(t)
+
+	err := manager.UninstallExtension("'; DROP TABLE users; --")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, duckdb.ErrUninstallNotSupported)
+}
+
+func TestExtensionManager_RepositoryURL(t *testing.T) {
+	db, _ := setupBasicExtensionTestDB(t)
+	repository := t.TempDir()
+	manager := duckdb.NewExtensionManager(db, &duckdb.ExtensionConfig{RepositoryURL: repository})
+
+	// The repository is a local directory without the extension, so DuckDB
+	// reports the path it looked in
+	err := manager.InstallExtension("h3")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), repository+"/v")
+
+	err = manager.InstallExtensionVersion("h3", "v4.1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), repository+"/h3/v4.1/")
+
+	named := duckdb.NewExtensionManager(db, &duckdb.ExtensionConfig{RepositoryURL: "community", Timeout: 5 * time.Second})
+	err = named.ForceInstallExtension("h3")
+	if err != nil {
+		assert.NotContains(t, err.Error(), "local extension", "named repositories are not paths")
+	}
+}
+
+func TestExtensionAwareDialector_AllowUnsigned(t *testing.T) {
+	config := &duckdb.ExtensionConfig{AllowUnsigned: true}
+	db, err := gorm.Open(duckdb.OpenWithExtensions(":memory:", config), &gorm.Config{})
+	require.NoError(t, err)
+
+	var allowed bool
+	require.NoError(t, db.Raw("SELECT current_setting('allow_unsigned_extensions')").Scan(&allowed).Error)
+	assert.True(t, allowed)
+
+	manager, err := duckdb.GetExtensionManager(db)
+	require.NoError(t, err)
+	err = manager.LoadExtension("not_an_extension")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "allow_unsigned_extensions")
+
+	// Concurrent loads share the manager's unsigned check
+	fresh := duckdb.NewExtensionManager(db, config)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Error(t, fresh.LoadExtension("not_an_extension"))
+		}()
+	}
+	wg.Wait()
+
+	// A database opened without the option cannot switch it on later
+	plain, _ := setupBasicExtensionTestDB(t)
+	manager = duckdb.NewExtensionManager(plain, &duckdb.ExtensionConfig{AllowUnsigned: true})
+	err = manager.LoadExtension("not_an_extension")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "allow_unsigned_extensions=true")
+}
+
+func TestExtensionManager_LoadExtensionFromPath(t *testing.T) {
+	dir := t.TempDir()
+	fake := filepath.Join(dir, "fake.duckdb_extension")
+	require.NoError(t, os.WriteFile(fake, []byte("not an extension"), 0o600))
+
+	plain, _ := setupBasicExtensionTestDB(t)
+	err := duckdb.NewExtensionManager(plain, &duckdb.ExtensionConfig{}).LoadExtensionFromPath(fake)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires AllowUnsigned")
+
+	db, err := gorm.Open(duckdb.OpenWithExtensions(":memory:", &duckdb.ExtensionConfig{AllowUnsigned: true}), &gorm.Config{})
+	require.NoError(t, err)
+	manager, err := duckdb.GetExtensionManager(db)
+	require.NoError(t, err)
+
+	err = manager.LoadExtensionFromPath(filepath.Join(dir, "missing.duckdb_extension"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	err = manager.LoadExtensionFromPath(filepath.Join(dir, "fake.so"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not end in .duckdb_extension")
+
+	err = manager.LoadExtensionFromPath(dir + "/../" + filepath.Base(dir) + "/fake.duckdb_extension")
+	require.Error(t, err, "a file that is not an extension build is rejected by DuckDB")
+	assert.Contains(t, err.Error(), fake)
+	assert.False(t, manager.IsExtensionLoaded("fake"))
+}
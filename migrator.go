@@ -4,8 +4,11 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -14,21 +17,28 @@ import (
 )
 
 // normalizeTable splits and strips quotes from a table identifier which may be
-// schema-qualified (e.g. "schema"."table" or schema.table). Returns schema
-// (may be empty) and table name.
-func normalizeTable(table string) (string, string) {
+// qualified by schema and catalog (e.g. "db"."schema"."table", schema.table or
+// table). Returns catalog and schema (empty when not given) and table name.
+func normalizeTable(table string) (string, string, string) {
 	if table == "" {
-		return "", ""
+		return "", "", ""
 	}
 	// Remove escaped quotes/backticks
 	t := strings.ReplaceAll(table, `\"`, "")
 	t = strings.ReplaceAll(t, `\`+"`", "")
 	t = strings.ReplaceAll(t, `"`, "")
 	t = strings.Trim(t, "`\"")
-	if parts := strings.SplitN(t, ".", 2); len(parts) == 2 {
-		return strings.Trim(parts[0], "`\""), strings.Trim(parts[1], "`\"")
+	parts := strings.SplitN(t, ".", 3)
+	for i := range parts {
+		parts[i] = strings.Trim(parts[i], "`\"")
 	}
-	return "", t
+	switch len(parts) {
+	case 3:
+		return parts[0], parts[1], parts[2]
+	case 2:
+		return "", parts[0], parts[1]
+	}
+	return "", "", t
 }
 
 // resolveTableName attempts to determine the table identifier for a given value
@@ -79,6 +89,61 @@ func isAlreadyExistsError(err error) bool {
 // Migrator implements gorm.Migrator interface for DuckDB database.
 type Migrator struct {
 	migrator.Migrator
+
+	// catalog caches the column and index names HasColumn and HasIndex read
+	// per table. Schema changes made through this Migrator clear it.
+	catalog *catalogCache
+}
+
+// catalogCache holds lower-cased column or index names per lower-cased table.
+type catalogCache struct {
+	mu   sync.Mutex
+	sets map[catalogKey]map[string]bool
+}
+
+type catalogKey struct {
+	kind  string // "columns" or "indexes"
+	table string
+}
+
+func newCatalogCache() *catalogCache {
+	return &catalogCache{sets: map[catalogKey]map[string]bool{}}
+}
+
+// contains reports whether name is among the kind names of table, loading
+// them with load on first use. Load failures are not cached.
+func (c *catalogCache) contains(kind, table, name string, load func() ([]string, error)) bool {
+	key := catalogKey{kind: kind, table: strings.ToLower(table)}
+	if c != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if set, ok := c.sets[key]; ok {
+			return set[strings.ToLower(name)]
+		}
+	}
+
+	names, err := load()
+	if err != nil {
+		return false
+	}
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[strings.ToLower(n)] = true
+	}
+	if c != nil {
+		c.sets[key] = set
+	}
+	return set[strings.ToLower(name)]
+}
+
+// reset drops everything cached, after a schema change
+func (c *catalogCache) reset() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sets = map[catalogKey]map[string]bool{}
 }
 
 // isAutoIncrementField checks if a field is an auto-increment field
@@ -86,26 +151,107 @@ func (m Migrator) isAutoIncrementField(field *schema.Field) bool {
 	return field.AutoIncrement || (!field.HasDefaultValue && field.DataType == schema.Uint)
 }
 
-// CurrentDatabase returns the current database name.
-func (m Migrator) CurrentDatabase() (name string) {
+// sequenceName returns the sequence behind an auto-increment column. The
+// sequence of a qualified table (schema.table or database.schema.table) is
+// created next to it, since a default cannot draw from another database.
+func sequenceName(table, column string) string {
+	catalogName, schemaName, tableName := normalizeTable(table)
+	name := "seq_" + strings.ToLower(tableName) + "_" + strings.ToLower(column)
+	if schemaName != "" {
+		name = schemaName + "." + name
+	}
+	if catalogName != "" {
+		name = catalogName + "." + name
+	}
+	return name
+}
+
+// createSequenceSQL returns the CREATE SEQUENCE statement for the sequence
+// name behind field. A sequence tag sets its options, e.g.
+// gorm:"sequence:start=1000,increment=2" for interleaved ID ranges; start,
+// increment, minvalue and maxvalue take integers and cycle needs no value.
+func createSequenceSQL(name string, field *schema.Field) (string, error) {
+	sql := "CREATE SEQUENCE IF NOT EXISTS " + quoteQualifiedName(name)
+	tag, ok := field.TagSettings["SEQUENCE"]
+	if !ok {
+		return sql + " START 1", nil
+	}
+
+	clauses := map[string]string{"start": "START WITH", "increment": "INCREMENT BY", "minvalue": "MINVALUE", "maxvalue": "MAXVALUE"}
+	for _, option := range strings.Split(tag, ",") {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(option), "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		switch {
+		case key == "":
+		case key == "cycle" && !hasValue:
+			sql += " CYCLE"
+		case clauses[key] != "" && hasValue:
+			n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return "", fmt.Errorf("invalid sequence option %q for field %s", option, field.Name)
+			}
+			sql += " " + clauses[key] + " " + strconv.FormatInt(n, 10)
+		default:
+			return "", fmt.Errorf("unknown sequence option %q for field %s", option, field.Name)
+		}
+	}
+	return sql, nil
+}
+
+// CurrentDatabase returns the name of the catalog unqualified names resolve
+// to, which follows USE. It is "main" only when the name cannot be read.
+func (m Migrator) CurrentDatabase() string {
+	for _, query := range []string{"SELECT current_database()", "SELECT current_catalog()"} {
+		if name := m.currentName(query); name != "" {
+			return name
+		}
+	}
+	return "main"
+}
+
+// CurrentSchema returns the schema unqualified names resolve to, the first
+// schema of the search path. It is "main" when the name cannot be read.
+func (m Migrator) CurrentSchema() string {
+	if name := m.currentName("SELECT current_schema()"); name != "" {
+		return name
+	}
+	return "main"
+}
+
+// currentName runs a query returning a single name, or "" on failure
+func (m Migrator) currentName(query string) string {
 	if m.DB == nil {
-		return "main"
+		return ""
 	}
-	row := m.DB.Raw("SELECT current_database()").Row()
-	if row == nil {
-		return "main"
+	var name sql.NullString
+	if err := m.DB.Raw(query).Scan(&name).Error; err != nil {
+		return ""
 	}
-	if err := row.Scan(&name); err != nil {
-		return "main"
+	return name.String
+}
+
+// DataTypeOf returns the column type of field. When migrations declare foreign
+// keys, a uint foreign key takes the type of the primary key it references,
+// as DuckDB requires both columns of a constraint to have the same type.
+func (m Migrator) DataTypeOf(field *schema.Field) string {
+	if field != nil && field.DataType == schema.Uint && !field.PrimaryKey && m.createsForeignKeys() {
+		if primaryKey := referencedPrimaryKey(field); primaryKey != nil && primaryKey.DataType == schema.Uint {
+			return m.Dialector.DataTypeOf(primaryKey)
+		}
 	}
-	return
+	return m.Dialector.DataTypeOf(field)
+}
+
+// createsForeignKeys reports whether CreateTable declares foreign keys
+func (m Migrator) createsForeignKeys() bool {
+	return !m.DB.DisableForeignKeyConstraintWhenMigrating && !m.DB.IgnoreRelationshipsWhenMigrating
 }
 
 // FullDataTypeOf returns the full data type for a field including constraints.
 // Override FullDataTypeOf to prevent GORM from adding duplicate PRIMARY KEY clauses
 func (m Migrator) FullDataTypeOf(field *schema.Field) clause.Expr {
-	// Get the base data type from our dialector
-	dataType := m.Dialector.DataTypeOf(field)
+	// Get the base data type
+	dataType := m.DataTypeOf(field)
 
 	expr := clause.Expr{SQL: dataType}
 
@@ -124,7 +270,7 @@ func (m Migrator) FullDataTypeOf(field *schema.Field) clause.Expr {
 			}
 
 			if tableName != "" {
-				expr.SQL = "BIGINT DEFAULT nextval('seq_" + strings.ToLower(tableName) + "_" + strings.ToLower(field.DBName) + "')"
+				expr.SQL = "BIGINT DEFAULT nextval(" + quoteLiteral(sequenceName(tableName, field.DBName)) + ")"
 			}
 		} else {
 			// Make sure the data type is clean for non-auto-increment primary keys
@@ -156,30 +302,67 @@ func (m Migrator) FullDataTypeOf(field *schema.Field) clause.Expr {
 	}
 
 	// Handle defaults for non-primary key fields only
-	if field.HasDefaultValue && (field.DefaultValueInterface != nil || field.DefaultValue != "") {
-		if field.DefaultValueInterface != nil {
-			defaultStmt := &gorm.Statement{Vars: []interface{}{field.DefaultValueInterface}}
-			m.BindVarTo(defaultStmt, defaultStmt, field.DefaultValueInterface)
-			expr.SQL += " DEFAULT " + m.Explain(defaultStmt.SQL.String(), field.DefaultValueInterface)
-		} else if field.DefaultValue != "(-)" {
-			expr.SQL += " DEFAULT " + field.DefaultValue
+	expr.SQL += m.defaultClause(field)
+
+	// Comments are set with COMMENT ON after the column exists, see setComments
+
+	return expr
+}
+
+// defaultClause returns " DEFAULT <value>" for a field with a default tag, or ""
+func (m Migrator) defaultClause(field *schema.Field) string {
+	if !field.HasDefaultValue {
+		return ""
+	}
+	if field.DefaultValueInterface != nil {
+		if literal, err := sqlLiteral(field.DefaultValueInterface); err == nil {
+			return " DEFAULT " + literal
 		}
 	}
+	if field.DefaultValue != "" && field.DefaultValue != "(-)" {
+		return " DEFAULT " + field.DefaultValue
+	}
+	return ""
+}
 
-	if field.Comment != "" {
-		expr.SQL += " COMMENT '" + field.Comment + "'"
+// setComments runs COMMENT ON COLUMN for the given fields that have a comment
+// tag, and COMMENT ON TABLE when the model has a TableComment() string method.
+// DuckDB does not accept comments inside column definitions.
+func (m Migrator) setComments(stmt *gorm.Statement, table string, fields []*schema.Field) error {
+	conn, ctx := m.DB.Statement.ConnPool, m.DB.Statement.Context
+	quotedTable := stmt.Quote(table)
+
+	if stmt.Schema != nil {
+		if commenter, ok := reflect.New(stmt.Schema.ModelType).Interface().(interface{ TableComment() string }); ok {
+			if comment := commenter.TableComment(); comment != "" {
+				if _, err := conn.ExecContext(ctx, "COMMENT ON TABLE "+quotedTable+" IS "+quoteLiteral(comment)); err != nil {
+					return fmt.Errorf("failed to comment on table %s: %w", table, err)
+				}
+			}
+		}
 	}
 
-	return expr
+	for _, field := range fields {
+		if field.DBName == "" || field.Comment == "" {
+			continue
+		}
+		commentSQL := fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", quotedTable, stmt.Quote(field.DBName), quoteLiteral(field.Comment))
+		if _, err := conn.ExecContext(ctx, commentSQL); err != nil {
+			return fmt.Errorf("failed to comment on column %s.%s: %w", table, field.DBName, err)
+		}
+	}
+	return nil
 }
 
 // AlterColumn modifies a column definition in DuckDB, handling syntax limitations.
 func (m Migrator) AlterColumn(value interface{}, field string) error {
+	defer m.catalog.reset()
+
 	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		if stmt.Schema != nil {
 			if field := stmt.Schema.LookUpField(field); field != nil {
 				// For ALTER COLUMN, only use the base data type without defaults
-				baseType := m.Dialector.DataTypeOf(field)
+				baseType := m.DataTypeOf(field)
 
 				// Clean the base type - remove any DEFAULT clauses
 				baseType = strings.Split(baseType, " DEFAULT")[0]
@@ -200,6 +383,8 @@ func (m Migrator) AlterColumn(value interface{}, field string) error {
 
 // RenameColumn renames a column in the database table.
 func (m Migrator) RenameColumn(value interface{}, oldName, newName string) error {
+	defer m.catalog.reset()
+
 	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		if stmt.Schema != nil {
 			if field := stmt.Schema.LookUpField(oldName); field != nil {
@@ -224,6 +409,8 @@ func (m Migrator) RenameColumn(value interface{}, oldName, newName string) error
 
 // RenameIndex renames an index in the database.
 func (m Migrator) RenameIndex(value interface{}, oldName, newName string) error {
+	defer m.catalog.reset()
+
 	err := m.RunWithValue(value, func(_ *gorm.Statement) error {
 		return m.DB.Exec(
 			"ALTER INDEX ? RENAME TO ?",
@@ -238,6 +425,8 @@ func (m Migrator) RenameIndex(value interface{}, oldName, newName string) error
 
 // DropIndex drops an index from the database.
 func (m Migrator) DropIndex(value interface{}, name string) error {
+	defer m.catalog.reset()
+
 	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		if stmt.Schema != nil {
 			if idx := stmt.Schema.LookIndex(name); idx != nil {
@@ -268,6 +457,456 @@ func (m Migrator) DropConstraint(value interface{}, name string) error {
 	return nil
 }
 
+// AddPrimaryKey adds a primary key over columns to an existing table, e.g. one
+// created by CTAS or COPY. Columns may be field or column names and default to
+// the model's primary fields. DuckDB rejects the ALTER when the table already
+// has a primary key or the existing rows are not unique on columns.
+func (m Migrator) AddPrimaryKey(value interface{}, columns ...string) error {
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if len(columns) == 0 && stmt.Schema != nil {
+			for _, field := range stmt.Schema.PrimaryFields {
+				columns = append(columns, field.DBName)
+			}
+		}
+		if len(columns) == 0 {
+			return fmt.Errorf("no primary key columns given for table %s", stmt.Table)
+		}
+
+		keyColumns := make([]interface{}, len(columns))
+		for i, column := range columns {
+			if stmt.Schema != nil {
+				if field := stmt.Schema.LookUpField(column); field != nil {
+					column = field.DBName
+				}
+			}
+			keyColumns[i] = clause.Column{Name: column}
+		}
+
+		catalogName, schemaName, tableName := normalizeTable(m.resolveTableName(value, stmt))
+		var existing int64
+		if err := m.DB.Raw(
+			`SELECT count(*) FROM information_schema.table_constraints
+			WHERE table_catalog = COALESCE(NULLIF(?, ''), current_database())
+				AND table_schema = COALESCE(NULLIF(?, ''), current_schema())
+				AND lower(table_name) = lower(?) AND constraint_type = 'PRIMARY KEY'`,
+			catalogName, schemaName, tableName,
+		).Scan(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return fmt.Errorf("table %s already has a primary key", stmt.Table)
+		}
+
+		return m.DB.Exec("ALTER TABLE ? ADD PRIMARY KEY ?", m.CurrentTable(stmt), keyColumns).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add primary key: %w", err)
+	}
+	return nil
+}
+
+// ColumnSpec describes a column for CreateTableFromColumns.
+type ColumnSpec struct {
+	Name string
+	// Type is a DuckDB type such as "VARCHAR", "DECIMAL(10,2)" or "INTEGER[]"
+	Type    string
+	NotNull bool
+	// Default is a literal value (string, bool, number or time.Time) rendered as
+	// a quoted SQL literal, or a clause.Expr whose SQL is used verbatim for
+	// expressions like current_timestamp. Nil means no default.
+	Default    interface{}
+	PrimaryKey bool
+	// AutoIncrement draws values from a sequence named seq_<table>_<column>
+	AutoIncrement bool
+}
+
+// columnTypePattern matches a plain column type: a type name with an optional
+// numeric (precision[, scale]) and [] or [n] list suffixes, such as INTEGER,
+// DECIMAL(10,2), VARCHAR[] or FLOAT[3]
+var columnTypePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\s*\(\s*\d+\s*(,\s*\d+\s*)?\))?(\[\d*\])*$`)
+
+// CreateTableFromColumns creates table name from column specs rather than a Go
+// struct, for schemas only known at runtime. Identifiers are quoted, defaults
+// are rendered as literals and types must be plain type names (see
+// columnTypePattern), so specs built from user input cannot inject SQL.
+func (m Migrator) CreateTableFromColumns(name string, columns []ColumnSpec) error {
+	defer m.catalog.reset()
+
+	if len(columns) == 0 {
+		return fmt.Errorf("failed to create table %s: no columns", name)
+	}
+	_, _, tableName := normalizeTable(name)
+
+	var table strings.Builder
+	m.Dialector.QuoteTo(&table, name)
+
+	definitions := make([]string, 0, len(columns)+1)
+	var primaryKeys, sequences []string
+	for _, column := range columns {
+		if column.Name == "" {
+			return fmt.Errorf("failed to create table %s: column without a name", name)
+		}
+		if !columnTypePattern.MatchString(column.Type) {
+			return fmt.Errorf("failed to create table %s: invalid type %q for column %s", name, column.Type, column.Name)
+		}
+
+		definition := quoteIdentifier(column.Name) + " " + column.Type
+		if column.NotNull {
+			definition += " NOT NULL"
+		}
+		switch {
+		case column.AutoIncrement:
+			sequence := sequenceName(tableName, column.Name)
+			sequences = append(sequences, sequence)
+			definition += " DEFAULT nextval(" + quoteLiteral(sequence) + ")"
+		case column.Default != nil:
+			literal, err := sqlLiteral(column.Default)
+			if err != nil {
+				return fmt.Errorf("failed to create table %s: default for column %s: %w", name, column.Name, err)
+			}
+			definition += " DEFAULT " + literal
+		}
+		if column.PrimaryKey {
+			primaryKeys = append(primaryKeys, quoteIdentifier(column.Name))
+		}
+		definitions = append(definitions, definition)
+	}
+	if len(primaryKeys) > 0 {
+		definitions = append(definitions, "PRIMARY KEY ("+strings.Join(primaryKeys, ", ")+")")
+	}
+
+	for _, sequence := range sequences {
+		if err := m.DB.Exec("CREATE SEQUENCE IF NOT EXISTS " + quoteQualifiedName(sequence) + " START 1").Error; err != nil {
+			return fmt.Errorf("failed to create sequence %s: %w", sequence, err)
+		}
+	}
+	createSQL := fmt.Sprintf("CREATE TABLE %s (%s)", table.String(), strings.Join(definitions, ", "))
+	if err := m.DB.Exec(createSQL).Error; err != nil {
+		return fmt.Errorf("failed to create table %s: %w", name, err)
+	}
+	return nil
+}
+
+// quoteIdentifier quotes a single identifier, doubling embedded quotes
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteQualifiedName quotes each dot-separated part of a qualified name
+func quoteQualifiedName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = quoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// sqlLiteral renders a Go value as a DuckDB literal for use in DDL
+func sqlLiteral(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case clause.Expr:
+		return v.SQL, nil
+	case string:
+		return quoteLiteral(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return "TIMESTAMP " + quoteLiteral(v.Format("2006-01-02 15:04:05.999999")), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64), nil
+	}
+	return "", fmt.Errorf("unsupported default value type %T", value)
+}
+
+// AutoMigrate runs gorm's auto migration and clears the catalog cache. On a
+// database opened read-only nothing is created or altered: the tables only
+// need to exist, and a missing one is reported with ErrReadOnly.
+func (m Migrator) AutoMigrate(values ...interface{}) error {
+	defer m.catalog.reset()
+	if dialector, ok := m.Dialector.(Dialector); ok && dialector.Config != nil && dialector.ReadOnly {
+		for _, value := range m.ReorderModels(values, true) {
+			if !m.HasTable(value) {
+				return fmt.Errorf("cannot create table for %T: %w", value, ErrReadOnly)
+			}
+		}
+		return nil
+	}
+	return m.Migrator.AutoMigrate(values...)
+}
+
+// AddColumn adds a column and clears the catalog cache. DuckDB cannot add a
+// column with constraints, so the column is added with its type and default,
+// NOT NULL is set once the default has filled the existing rows (DuckDB
+// refuses this on tables with indexes; the column then stays nullable with a
+// warning), and UNIQUE becomes a unique index. Enum types the column uses are created first.
+func (m Migrator) AddColumn(value interface{}, name string) error {
+	defer m.catalog.reset()
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema == nil {
+			return m.Migrator.AddColumn(value, name)
+		}
+		field := stmt.Schema.LookUpField(name)
+		if field == nil {
+			return fmt.Errorf("failed to look up field with name: %s", name)
+		}
+		if field.IgnoreMigration {
+			return nil
+		}
+		enums, err := m.createEnumTypes(stmt, value, []*schema.Field{field})
+		if err != nil {
+			return err
+		}
+
+		table := m.CurrentTable(stmt)
+		column := clause.Column{Name: field.DBName}
+		definition := m.DataTypeOf(field)
+		if enum, ok := enums[field.DBName]; ok {
+			definition = enum
+		}
+		if !field.PrimaryKey {
+			definition += m.defaultClause(field)
+		}
+		if !field.NotNull && !field.PrimaryKey {
+			if err := m.DB.Exec("ALTER TABLE ? ADD COLUMN ? "+definition, table, column).Error; err != nil {
+				return err
+			}
+		} else if err := m.DB.Transaction(func(tx *gorm.DB) error {
+			// Added together so the column is not left behind nullable when
+			// SET NOT NULL fails; DuckDB cannot alter a table that has indexes
+			if err := tx.Exec("ALTER TABLE ? ADD COLUMN ? "+definition, table, column).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("ALTER TABLE ? ALTER COLUMN ? SET NOT NULL", table, column).Error; err != nil {
+				return fmt.Errorf("failed to set %s not null: %w", field.DBName, err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		if field.Unique {
+			if err := m.createUniqueIndex(stmt, field); err != nil {
+				return err
+			}
+		}
+		if field.Comment != "" {
+			return m.setComments(stmt, stmt.Table, []*schema.Field{field})
+		}
+		return nil
+	})
+}
+
+// createUniqueIndex makes an existing column unique. DuckDB cannot add a
+// UNIQUE constraint to a table, so a unique index named like the constraint
+// gorm would create takes its place.
+func (m Migrator) createUniqueIndex(stmt *gorm.Statement, field *schema.Field) error {
+	index := clause.Column{Name: m.DB.NamingStrategy.UniqueName(stmt.Table, field.DBName)}
+	if err := m.DB.Exec("CREATE UNIQUE INDEX IF NOT EXISTS ? ON ? (?)",
+		index, m.CurrentTable(stmt), clause.Column{Name: field.DBName}).Error; err != nil {
+		return fmt.Errorf("failed to make %s unique: %w", field.DBName, err)
+	}
+	return nil
+}
+
+// MigrateColumnUnique keeps a column's uniqueness in step with its unique tag
+// through the unique index of createUniqueIndex. A UNIQUE constraint from
+// CREATE TABLE cannot be dropped in DuckDB and is left with a warning.
+func (m Migrator) MigrateColumnUnique(value interface{}, field *schema.Field, columnType gorm.ColumnType) error {
+	unique, ok := columnType.Unique()
+	if !ok || field.PrimaryKey {
+		return nil
+	}
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		index := m.DB.NamingStrategy.UniqueName(stmt.Table, field.DBName)
+		hasIndex := m.HasIndex(value, index)
+		switch {
+		case field.Unique && !unique && !hasIndex:
+			defer m.catalog.reset()
+			return m.createUniqueIndex(stmt, field)
+		case !field.Unique && hasIndex:
+			return m.DropIndex(value, index)
+		case !field.Unique && unique:
+			m.DB.Logger.Warn(stmt.Context, "column %s of %s keeps its UNIQUE constraint: DuckDB cannot drop it", field.DBName, stmt.Table)
+		}
+		return nil
+	})
+}
+
+// DropColumn drops a column and clears the catalog cache.
+func (m Migrator) DropColumn(value interface{}, name string) error {
+	defer m.catalog.reset()
+	return m.Migrator.DropColumn(value, name)
+}
+
+// MigrateColumn alters a column to match its field and clears the catalog cache.
+func (m Migrator) MigrateColumn(value interface{}, field *schema.Field, columnType gorm.ColumnType) error {
+	defer m.catalog.reset()
+	// An ENUM column reports its values rather than the name of its type, so
+	// compare it by the name the field expects instead of altering it
+	if isEnumField(field) && strings.HasPrefix(strings.ToUpper(columnType.DatabaseTypeName()), "ENUM") {
+		columnType = enumColumnType{gormColumnType: columnType, name: m.Dialector.DataTypeOf(field)}
+	}
+	if err := m.Migrator.MigrateColumn(value, field, columnType); err != nil {
+		return err
+	}
+	// Keep the column comment in step with the comment tag
+	if comment, _ := columnType.Comment(); field.Comment != "" && field.Comment != comment {
+		return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			return m.setComments(stmt, stmt.Table, []*schema.Field{field})
+		})
+	}
+	return nil
+}
+
+// CreateIndex creates the index declared by the index tag named name (or on
+// the field named name) as CREATE [UNIQUE] INDEX IF NOT EXISTS, so indexes
+// created with the table are not created twice. Expression columns
+// (expression:lower(email)) and USING ART are supported; DuckDB has no partial
+// indexes, so a where option is rejected rather than silently dropped.
+func (m Migrator) CreateIndex(value interface{}, name string) error {
+	defer m.catalog.reset()
+
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema == nil {
+			return fmt.Errorf("failed to get schema")
+		}
+		idx := stmt.Schema.LookIndex(name)
+		if idx == nil {
+			return fmt.Errorf("failed to create index with name %s", name)
+		}
+		if idx.Where != "" {
+			return fmt.Errorf("failed to create index %s: DuckDB does not support partial indexes (where:%s)", idx.Name, idx.Where)
+		}
+
+		createIndexSQL := "CREATE "
+		if idx.Class != "" {
+			createIndexSQL += idx.Class + " "
+		}
+		createIndexSQL += "INDEX IF NOT EXISTS ? ON ?"
+		if idx.Type != "" {
+			createIndexSQL += " USING " + idx.Type
+		}
+		createIndexSQL += " ?"
+		if idx.Option != "" {
+			createIndexSQL += " " + idx.Option
+		}
+
+		opts := m.BuildIndexOptions(idx.Fields, stmt)
+		return m.DB.Exec(createIndexSQL, clause.Column{Name: idx.Name}, m.CurrentTable(stmt), opts).Error
+	})
+}
+
+// DropTable drops tables and clears the catalog cache.
+func (m Migrator) DropTable(values ...interface{}) error {
+	defer m.catalog.reset()
+	return m.Migrator.DropTable(values...)
+}
+
+// DropSequence drops the sequence behind the auto-increment field of value's
+// table; field may be a field or column name. DuckDB refuses while a column
+// default still draws from the sequence, so drop the table first.
+func (m Migrator) DropSequence(value interface{}, field string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		sequence := m.sequenceOf(stmt, field)
+		if err := m.DB.Exec("DROP SEQUENCE IF EXISTS " + quoteQualifiedName(sequence)).Error; err != nil {
+			return fmt.Errorf("failed to drop sequence %s: %w", sequence, err)
+		}
+		return nil
+	})
+}
+
+// CurrentSequenceValue returns the last value drawn from the sequence behind
+// the auto-increment field of value's table, by any connection. It fails when
+// no value has been drawn yet.
+func (m Migrator) CurrentSequenceValue(value interface{}, field string) (int64, error) {
+	var current *int64
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		sequence := m.sequenceOf(stmt, field)
+		var found []*int64
+		if err := m.DB.Raw(`SELECT last_value FROM duckdb_sequences()
+			WHERE database_name = current_database() AND schema_name = current_schema() AND sequence_name = ?`,
+			sequence).Scan(&found).Error; err != nil {
+			return fmt.Errorf("failed to read sequence %s: %w", sequence, err)
+		}
+		switch {
+		case len(found) == 0:
+			return fmt.Errorf("sequence %s does not exist", sequence)
+		case found[0] == nil:
+			return fmt.Errorf("sequence %s has not been used yet", sequence)
+		}
+		current = found[0]
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return *current, nil
+}
+
+func (m Migrator) sequenceOf(stmt *gorm.Statement, field string) string {
+	table := stmt.Table
+	if stmt.Schema != nil {
+		table = stmt.Schema.Table
+		if f := stmt.Schema.LookUpField(field); f != nil {
+			field = f.DBName
+		}
+	}
+	return sequenceName(table, field)
+}
+
+// RenameTable renames a table and clears the catalog cache.
+func (m Migrator) RenameTable(oldName, newName interface{}) error {
+	defer m.catalog.reset()
+	return m.Migrator.RenameTable(oldName, newName)
+}
+
+// RecreateTable drops the table backing value, together with the sequences of
+// its auto-increment columns, and creates it again from the model in a single
+// transaction. Every row in the table is lost, so this is meant for development
+// and test setups.
+func (m Migrator) RecreateTable(value interface{}) error {
+	defer m.catalog.reset()
+
+	return m.DB.Transaction(func(tx *gorm.DB) error {
+		txMigrator, ok := tx.Migrator().(Migrator)
+		if !ok {
+			return fmt.Errorf("unexpected migrator %T", tx.Migrator())
+		}
+
+		if err := txMigrator.RunWithValue(value, func(stmt *gorm.Statement) error {
+			if stmt.Schema == nil {
+				return fmt.Errorf("failed to recreate table %s: model has no schema", stmt.Table)
+			}
+			table := stmt.Schema.Table
+			tx.Logger.Warn(stmt.Context, "recreating table %s: existing rows are discarded", table)
+
+			if err := tx.Exec("DROP TABLE IF EXISTS " + stmt.Quote(table)).Error; err != nil {
+				return fmt.Errorf("failed to drop table %s: %w", table, err)
+			}
+			for _, field := range stmt.Schema.Fields {
+				if field.PrimaryKey && (field.AutoIncrement || (!field.HasDefaultValue && field.DataType == schema.Uint)) {
+					sequence := sequenceName(table, field.DBName)
+					if err := tx.Exec("DROP SEQUENCE IF EXISTS " + quoteQualifiedName(sequence)).Error; err != nil {
+						return fmt.Errorf("failed to drop sequence %s: %w", sequence, err)
+					}
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		return txMigrator.CreateTable(value)
+	})
+}
+
 // HasTable checks if a table exists in the database.
 func (m Migrator) HasTable(value interface{}) bool {
 	var count int64
@@ -284,10 +923,12 @@ func (m Migrator) HasTable(value interface{}) bool {
 		}
 
 		// Normalize table identifier to handle quoted and schema-qualified names
-		_, tableName := normalizeTable(tableIdentifier)
+		catalogName, schemaName, tableName := normalizeTable(tableIdentifier)
 		rows, err := m.DB.Raw(
-			"SELECT count(*) FROM information_schema.tables WHERE lower(table_name) = lower(?) AND table_type = 'BASE TABLE'",
-			tableName,
+			`SELECT count(*) FROM information_schema.tables
+			WHERE lower(table_name) = lower(?) AND table_type = 'BASE TABLE'
+				AND (? = '' OR table_catalog = ?) AND (? = '' OR table_schema = ?)`,
+			tableName, catalogName, catalogName, schemaName, schemaName,
 		).Rows()
 		if err != nil {
 			return err
@@ -331,7 +972,7 @@ func (m Migrator) GetTables() (tableList []string, err error) {
 
 // HasColumn checks if a column exists in the database table.
 func (m Migrator) HasColumn(value interface{}, field string) bool {
-	var count int64
+	var found bool
 	_ = m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		name := field
 		if stmt.Schema != nil {
@@ -349,32 +990,28 @@ func (m Migrator) HasColumn(value interface{}, field string) bool {
 		} else {
 			tableIdentifier = fmt.Sprint(m.CurrentTable(stmt))
 		}
-		_, tableName := normalizeTable(tableIdentifier)
-		rows, err := m.DB.Raw(
-			"SELECT count(*) FROM information_schema.columns WHERE lower(table_name) = lower(?) AND lower(column_name) = lower(?)",
-			tableName, name,
-		).Rows()
-		if err != nil {
-			return nil
-		}
-		if rows == nil {
-			return nil
-		}
-		defer rows.Close()
-		if rows.Next() {
-			if err := rows.Scan(&count); err != nil {
-				return nil
-			}
-		}
+		catalogName, schemaName, tableName := normalizeTable(tableIdentifier)
+
+		found = m.catalog.contains("columns", tableIdentifier, name, func() ([]string, error) {
+			var names []string
+			err := m.DB.Raw(
+				`SELECT column_name FROM duckdb_columns() WHERE NOT internal AND lower(table_name) = lower(?)
+					AND (? = '' OR database_name = ?) AND (? = '' OR schema_name = ?)`,
+				tableName, catalogName, catalogName, schemaName, schemaName,
+			).Scan(&names).Error
+			return names, err
+		})
 		return nil
 	})
 
-	return count > 0
+	return found
 }
 
-// HasIndex checks if an index exists in the database.
+// HasIndex checks if an index exists in the database. name may be an index
+// name or a field whose index tag names it; the lookup uses duckdb_indexes()
+// like GetIndexes.
 func (m Migrator) HasIndex(value interface{}, name string) bool {
-	var count int64
+	var found bool
 	_ = m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		if stmt.Schema != nil {
 			if idx := stmt.Schema.LookIndex(name); idx != nil {
@@ -382,42 +1019,39 @@ func (m Migrator) HasIndex(value interface{}, name string) bool {
 			}
 		}
 
-		tableIdentifier := ""
-		if stmt != nil && stmt.Schema != nil && stmt.Schema.Table != "" {
-			tableIdentifier = stmt.Schema.Table
-		} else if stmt != nil && stmt.Table != "" {
-			tableIdentifier = stmt.Table
-		} else {
+		tableIdentifier := m.resolveTableName(value, stmt)
+		if tableIdentifier == "" {
 			tableIdentifier = fmt.Sprint(m.CurrentTable(stmt))
 		}
-		_, tableName := normalizeTable(tableIdentifier)
-		rows, err := m.DB.Raw(
-			"SELECT count(*) FROM information_schema.statistics WHERE lower(table_name) = lower(?) AND lower(index_name) = lower(?)",
-			tableName, name,
-		).Rows()
-		if err != nil {
-			return nil
-		}
-		if rows == nil {
-			return nil
-		}
-		defer rows.Close()
-		if rows.Next() {
-			if err := rows.Scan(&count); err != nil {
-				return nil
-			}
-		}
+		catalogName, schemaName, tableName := normalizeTable(tableIdentifier)
+
+		found = m.catalog.contains("indexes", tableIdentifier, name, func() ([]string, error) {
+			var names []string
+			err := m.DB.Raw(`SELECT index_name FROM duckdb_indexes()
+				WHERE database_name = COALESCE(NULLIF(?, ''), current_database())
+					AND schema_name = COALESCE(NULLIF(?, ''), current_schema())
+					AND lower(table_name) = lower(?)`,
+				catalogName, schemaName, tableName,
+			).Scan(&names).Error
+			return names, err
+		})
 		return nil
 	})
 
-	return count > 0
+	return found
 }
 
-// HasConstraint checks if a constraint exists in the database.
+// HasConstraint checks if a constraint exists in the database. DuckDB names
+// constraints itself, so a foreign key of the model is matched by its columns
+// and referenced table instead of by name.
 func (m Migrator) HasConstraint(value interface{}, name string) bool {
 	var count int64
 	_ = m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		constraint, table := m.GuessConstraintInterfaceAndTable(stmt, name)
+		if fk, ok := constraint.(*schema.Constraint); ok {
+			count = m.countForeignKeys(fk)
+			return nil
+		}
 		if constraint != nil {
 			name = constraint.GetName()
 		}
@@ -429,11 +1063,13 @@ func (m Migrator) HasConstraint(value interface{}, name string) bool {
 		} else {
 			tableIdentifier = fmt.Sprint(m.CurrentTable(stmt))
 		}
-		_, tableName := normalizeTable(tableIdentifier)
+		catalogName, schemaName, tableName := normalizeTable(tableIdentifier)
 
 		rows, err := m.DB.Raw(
-			"SELECT count(*) FROM information_schema.table_constraints WHERE lower(table_name) = lower(?) AND lower(constraint_name) = lower(?)",
-			tableName, name,
+			`SELECT count(*) FROM information_schema.table_constraints
+			WHERE lower(table_name) = lower(?) AND lower(constraint_name) = lower(?)
+				AND (? = '' OR table_catalog = ?) AND (? = '' OR table_schema = ?)`,
+			tableName, name, catalogName, catalogName, schemaName, schemaName,
 		).Rows()
 		if err != nil {
 			return nil
@@ -453,6 +1089,28 @@ func (m Migrator) HasConstraint(value interface{}, name string) bool {
 	return count > 0
 }
 
+func (m Migrator) countForeignKeys(constraint *schema.Constraint) int64 {
+	columns := make([]string, len(constraint.ForeignKeys))
+	for i, field := range constraint.ForeignKeys {
+		columns[i] = field.DBName
+	}
+	references := make([]string, len(constraint.References))
+	for i, field := range constraint.References {
+		references[i] = field.DBName
+	}
+
+	var count int64
+	_ = m.DB.Raw(`SELECT count(*) FROM duckdb_constraints()
+		WHERE database_name = current_database() AND schema_name = current_schema()
+			AND constraint_type = 'FOREIGN KEY' AND lower(table_name) = lower(?)
+			AND lower(referenced_table) = lower(?)
+			AND constraint_column_names = ? AND referenced_column_names = ?`,
+		constraint.Schema.Table, constraint.ReferenceSchema.Table,
+		StringArray(columns), StringArray(references),
+	).Scan(&count).Error
+	return count
+}
+
 // CreateView creates a database view.
 func (m Migrator) CreateView(name string, option gorm.ViewOption) error {
 	if option.Query == nil {
@@ -538,128 +1196,215 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 		}
 
 		// Normalize the table identifier
-		_, tableName := normalizeTable(tableIdentifier)
-
-		// Build query for this table
-		query := `
-			SELECT
-				c.column_name,
-				c.data_type,
-				CASE
-					WHEN c.character_maximum_length IS NOT NULL THEN c.data_type || '(' || c.character_maximum_length || ')'
-					WHEN c.numeric_precision IS NOT NULL AND c.numeric_scale IS NOT NULL THEN c.data_type || '(' || c.numeric_precision || ',' || c.numeric_scale || ')'
-					WHEN c.numeric_precision IS NOT NULL THEN c.data_type || '(' || c.numeric_precision || ')'
-					ELSE c.data_type
-				END as column_type,
-				CASE WHEN c.is_nullable = 'YES' THEN true ELSE false END as nullable,
-				c.column_default,
-				COALESCE(pk.is_primary_key, false) as is_primary_key,
-				CASE WHEN c.column_default LIKE '%nextval%' OR c.column_default LIKE '%seq_%' THEN true ELSE false END as is_auto_increment,
-				c.character_maximum_length,
-				c.numeric_precision,
-				c.numeric_scale,
-				COALESCE(uk.is_unique, false) as is_unique,
-				'' as column_comment
-			FROM information_schema.columns c
-			LEFT JOIN (
-				SELECT kcu.column_name, true as is_primary_key
-				FROM information_schema.table_constraints tc
-				JOIN information_schema.key_column_usage kcu ON tc.constraint_name = kcu.constraint_name
-				WHERE tc.constraint_type = 'PRIMARY KEY' AND lower(tc.table_name) = lower(?)
-			) pk ON c.column_name = pk.column_name
-			LEFT JOIN (
-				SELECT kcu.column_name, true as is_unique
-				FROM information_schema.table_constraints tc
-				JOIN information_schema.key_column_usage kcu ON tc.constraint_name = kcu.constraint_name
-				WHERE tc.constraint_type = 'UNIQUE' AND lower(tc.table_name) = lower(?)
-			) uk ON c.column_name = uk.column_name
-			WHERE lower(c.table_name) = lower(?)
-			ORDER BY c.ordinal_position
-		`
-
-		args := []interface{}{tableName, tableName, tableName}
-
-		rows, err := m.DB.Raw(query, args...).Rows()
+		catalogName, schemaName, tableName := normalizeTable(tableIdentifier)
 
+		snapshot, err := m.queryColumnTypes(catalogName, schemaName, tableName, stmt)
 		if err != nil {
 			return err
 		}
-		if rows == nil {
-			return nil
+		for _, cols := range snapshot {
+			columnTypes = append(columnTypes, cols...)
 		}
-		defer rows.Close()
+		return nil
+	})
 
-		var found int
-		for rows.Next() {
-			found++
-			var (
-				columnName, dataType, columnTypeStr, columnComment  string
-				columnDefault                                       sql.NullString
-				isNullable, isPrimaryKey, isAutoIncrement, isUnique bool
-				charMaxLength, numericPrecision, numericScale       sql.NullInt64
-			)
-
-			if scanErr := rows.Scan(
-				&columnName, &dataType, &columnTypeStr, &isNullable, &columnDefault,
-				&isPrimaryKey, &isAutoIncrement, &charMaxLength, &numericPrecision,
-				&numericScale, &isUnique, &columnComment,
-			); scanErr != nil {
-				// Skip malformed rows but continue processing others
-				continue
-			}
+	return columnTypes, err
+}
 
-			columnType := &migrator.ColumnType{
-				NameValue:          sql.NullString{String: columnName, Valid: true},
-				DataTypeValue:      sql.NullString{String: dataType, Valid: true},
-				ColumnTypeValue:    sql.NullString{String: columnTypeStr, Valid: true},
-				NullableValue:      sql.NullBool{Bool: isNullable, Valid: true},
-				PrimaryKeyValue:    sql.NullBool{Bool: isPrimaryKey, Valid: true},
-				AutoIncrementValue: sql.NullBool{Bool: isAutoIncrement, Valid: true},
-				UniqueValue:        sql.NullBool{Bool: isUnique, Valid: true},
-				CommentValue:       sql.NullString{String: columnComment, Valid: columnComment != ""},
-				DefaultValueValue:  columnDefault,
-				ScanTypeValue:      reflect.TypeOf(""), // Default to string type for safety
-			}
-
-			// Set length information defensively
-			if charMaxLength.Valid {
-				columnType.LengthValue = charMaxLength
-			} else {
-				// Prefer schema metadata if available (use closure stmt)
-				if stmt != nil && stmt.Schema != nil {
-					// Look up field by column name
-					if f := stmt.Schema.LookUpField(columnName); f != nil && f.Size > 0 {
-						columnType.LengthValue = sql.NullInt64{Int64: int64(f.Size), Valid: true}
-					} else {
-						// Try to parse from column_type string as fallback
-						if idx := strings.Index(columnTypeStr, "("); idx > 0 {
-							// naive parse between parentheses
-							end := strings.Index(columnTypeStr[idx+1:], ")")
-							if end > 0 {
-								if l, parseErr := strconv.ParseInt(columnTypeStr[idx+1:idx+1+end], 10, 64); parseErr == nil {
-									columnType.LengthValue = sql.NullInt64{Int64: l, Valid: true}
-								}
-							}
-						}
+// SchemaSnapshot returns every base table mapped to its column metadata,
+// gathered in a single information_schema query.
+func (m Migrator) SchemaSnapshot() (map[string][]gorm.ColumnType, error) {
+	return m.queryColumnTypes("", "", "", nil)
+}
+
+// columnType wraps migrator.ColumnType for columns read from information_schema.
+// There is no driver-level *sql.ColumnType behind them, so optional metadata
+// reports ok=false instead of falling through to a nil SQLColumnType.
+type columnType struct {
+	sqlColumnType
+}
+
+// sqlColumnType aliases migrator.ColumnType so embedding it does not shadow
+// the promoted ColumnType() method with a field of the same name.
+type sqlColumnType = migrator.ColumnType
+
+// Length returns the column length if known.
+func (ct columnType) Length() (int64, bool) {
+	if ct.LengthValue.Valid {
+		return ct.LengthValue.Int64, true
+	}
+	return 0, false
+}
+
+// DecimalSize returns precision and scale if the column is numeric.
+func (ct columnType) DecimalSize() (precision int64, scale int64, ok bool) {
+	if ct.DecimalSizeValue.Valid {
+		return ct.DecimalSizeValue.Int64, ct.ScaleValue.Int64, true
+	}
+	return 0, 0, false
+}
+
+// Comment returns the column comment if set.
+func (ct columnType) Comment() (string, bool) {
+	if ct.CommentValue.Valid {
+		return ct.CommentValue.String, true
+	}
+	return "", false
+}
+
+// DefaultValue returns the column default if set. String literals are
+// unquoted and boolean casts read as true or false, the form gorm keeps for
+// default tags, so unchanged defaults compare equal during AutoMigrate.
+func (ct columnType) DefaultValue() (string, bool) {
+	if !ct.DefaultValueValue.Valid {
+		return "", false
+	}
+	value := ct.DefaultValueValue.String
+	switch value {
+	case "CAST('t' AS BOOLEAN)":
+		return "true", true
+	case "CAST('f' AS BOOLEAN)":
+		return "false", true
+	}
+	if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") &&
+		!strings.Contains(strings.ReplaceAll(value[1:len(value)-1], "''", ""), "'") {
+		value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return value, true
+}
+
+// queryColumnTypes loads column metadata grouped by table. An empty tableName
+// loads every base table; catalogName and schemaName, when given, restrict the
+// tables to that database and schema. stmt, when given, supplies schema sizes
+// as a fallback.
+func (m Migrator) queryColumnTypes(catalogName, schemaName, tableName string, stmt *gorm.Statement) (map[string][]gorm.ColumnType, error) {
+	query := `
+		SELECT
+			c.table_name,
+			c.column_name,
+			c.data_type,
+			CASE
+				WHEN c.character_maximum_length IS NOT NULL THEN c.data_type || '(' || c.character_maximum_length || ')'
+				WHEN c.numeric_precision IS NOT NULL AND c.numeric_scale IS NOT NULL THEN c.data_type || '(' || c.numeric_precision || ',' || c.numeric_scale || ')'
+				WHEN c.numeric_precision IS NOT NULL THEN c.data_type || '(' || c.numeric_precision || ')'
+				ELSE c.data_type
+			END as column_type,
+			CASE WHEN c.is_nullable = 'YES' THEN true ELSE false END as nullable,
+			c.column_default,
+			COALESCE(pk.is_primary_key, false) as is_primary_key,
+			CASE WHEN c.column_default LIKE '%nextval%' OR c.column_default LIKE '%seq_%' THEN true ELSE false END as is_auto_increment,
+			c.character_maximum_length,
+			c.numeric_precision,
+			c.numeric_scale,
+			COALESCE(uk.is_unique, false) as is_unique,
+			'' as column_comment
+		FROM information_schema.columns c
+		JOIN information_schema.tables t
+			ON t.table_catalog = c.table_catalog AND t.table_schema = c.table_schema AND t.table_name = c.table_name
+		LEFT JOIN (
+			SELECT DISTINCT tc.table_catalog, tc.table_schema, tc.table_name, kcu.column_name, true as is_primary_key
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+				ON tc.constraint_name = kcu.constraint_name AND tc.table_catalog = kcu.table_catalog AND tc.table_schema = kcu.table_schema
+			WHERE tc.constraint_type = 'PRIMARY KEY'
+		) pk ON c.table_catalog = pk.table_catalog AND c.table_schema = pk.table_schema
+			AND lower(c.table_name) = lower(pk.table_name) AND c.column_name = pk.column_name
+		LEFT JOIN (
+			SELECT DISTINCT tc.table_catalog, tc.table_schema, tc.table_name, kcu.column_name, true as is_unique
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+				ON tc.constraint_name = kcu.constraint_name AND tc.table_catalog = kcu.table_catalog AND tc.table_schema = kcu.table_schema
+			WHERE tc.constraint_type = 'UNIQUE'
+		) uk ON c.table_catalog = uk.table_catalog AND c.table_schema = uk.table_schema
+			AND lower(c.table_name) = lower(uk.table_name) AND c.column_name = uk.column_name
+		WHERE t.table_type = 'BASE TABLE'
+	`
+
+	var args []interface{}
+	if tableName != "" {
+		query += " AND lower(c.table_name) = lower(?)"
+		args = append(args, tableName)
+	}
+	if catalogName != "" {
+		query += " AND c.table_catalog = ?"
+		args = append(args, catalogName)
+	}
+	if schemaName != "" {
+		query += " AND c.table_schema = ?"
+		args = append(args, schemaName)
+	}
+	query += " ORDER BY c.table_name, c.ordinal_position"
+
+	rows, err := m.DB.Raw(query, args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		return nil, nil
+	}
+	defer rows.Close()
+
+	snapshot := make(map[string][]gorm.ColumnType)
+	for rows.Next() {
+		var (
+			table, columnName, dataType, columnTypeStr, columnComment string
+			columnDefault                                             sql.NullString
+			isNullable, isPrimaryKey, isAutoIncrement, isUnique       bool
+			charMaxLength, numericPrecision, numericScale             sql.NullInt64
+		)
+
+		if scanErr := rows.Scan(
+			&table, &columnName, &dataType, &columnTypeStr, &isNullable, &columnDefault,
+			&isPrimaryKey, &isAutoIncrement, &charMaxLength, &numericPrecision,
+			&numericScale, &isUnique, &columnComment,
+		); scanErr != nil {
+			// Skip malformed rows but continue processing others
+			continue
+		}
+
+		ct := columnType{sqlColumnType{
+			NameValue:          sql.NullString{String: columnName, Valid: true},
+			DataTypeValue:      sql.NullString{String: dataType, Valid: true},
+			ColumnTypeValue:    sql.NullString{String: columnTypeStr, Valid: true},
+			NullableValue:      sql.NullBool{Bool: isNullable, Valid: true},
+			PrimaryKeyValue:    sql.NullBool{Bool: isPrimaryKey, Valid: true},
+			AutoIncrementValue: sql.NullBool{Bool: isAutoIncrement, Valid: true},
+			UniqueValue:        sql.NullBool{Bool: isUnique, Valid: true},
+			CommentValue:       sql.NullString{String: columnComment, Valid: columnComment != ""},
+			DefaultValueValue:  columnDefault,
+			ScanTypeValue:      reflect.TypeOf(""), // Default to string type for safety
+		}}
+
+		// Set length information defensively
+		if charMaxLength.Valid {
+			ct.LengthValue = charMaxLength
+		} else if stmt != nil && stmt.Schema != nil {
+			// Prefer schema metadata if available
+			if f := stmt.Schema.LookUpField(columnName); f != nil && f.Size > 0 {
+				ct.LengthValue = sql.NullInt64{Int64: int64(f.Size), Valid: true}
+			} else if idx := strings.Index(columnTypeStr, "("); idx > 0 {
+				// Try to parse from column_type string as fallback
+				end := strings.Index(columnTypeStr[idx+1:], ")")
+				if end > 0 {
+					if l, parseErr := strconv.ParseInt(columnTypeStr[idx+1:idx+1+end], 10, 64); parseErr == nil {
+						ct.LengthValue = sql.NullInt64{Int64: l, Valid: true}
 					}
 				}
 			}
+		}
 
-			// Set decimal size information
-			if numericPrecision.Valid {
-				columnType.DecimalSizeValue = numericPrecision
-				if numericScale.Valid {
-					columnType.ScaleValue = numericScale
-				}
+		// Set decimal size information
+		if numericPrecision.Valid {
+			ct.DecimalSizeValue = numericPrecision
+			if numericScale.Valid {
+				ct.ScaleValue = numericScale
 			}
-
-			columnTypes = append(columnTypes, columnType)
 		}
 
-		return rows.Err()
-	})
+		snapshot[table] = append(snapshot[table], ct)
+	}
 
-	return columnTypes, err
+	return snapshot, rows.Err()
 }
 
 // TableType returns comprehensive table type information
@@ -713,6 +1458,41 @@ func (m Migrator) TableType(value interface{}) (gorm.TableType, error) {
 	return result, err
 }
 
+// StorageStat is one column segment reported by PRAGMA storage_info. Count
+// is the number of rows the segment holds; segments of type VALIDITY track
+// NULLs for the column they belong to.
+type StorageStat struct {
+	RowGroupID  int64
+	ColumnName  string
+	ColumnID    int64
+	ColumnPath  string
+	SegmentID   int64
+	SegmentType string
+	Start       int64
+	Count       int64
+	Compression string
+	Stats       string
+	HasUpdates  bool
+	Persistent  bool
+}
+
+// StorageInfo returns the storage segments of the model's table, showing how
+// its columns are split into row groups and compressed. Tables only get
+// persistent, compressed segments once checkpointed to a database file.
+func (m Migrator) StorageInfo(value interface{}) ([]StorageStat, error) {
+	var stats []StorageStat
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Raw(`SELECT row_group_id, column_name, column_id, column_path, segment_id, segment_type,
+				start, count, compression, stats, has_updates, persistent
+			FROM pragma_storage_info(?)
+			ORDER BY row_group_id, column_id, column_path, segment_id`, stmt.Table).Scan(&stats).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read storage info: %w", err)
+	}
+	return stats, nil
+}
+
 // DuckDBIndex implements gorm.Index interface for DuckDB
 type DuckDBIndex struct {
 	TableName   string
@@ -747,19 +1527,107 @@ func (idx DuckDBIndex) Option() string {
 	return idx.Options
 }
 
-// GetIndexes returns comprehensive index information for the given value
+// GetIndexes returns the indexes of the given value's table from
+// duckdb_indexes(), followed by its PRIMARY KEY and UNIQUE constraints, which
+// DuckDB enforces with indexes it does not list there. Expression indexes
+// report the expression text as their column.
 func (m Migrator) GetIndexes(value interface{}) ([]gorm.Index, error) {
 	var indexes []gorm.Index
 
 	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		// DuckDB may not have complete information_schema.statistics support
-		// For now, return empty indexes to avoid errors
+		catalogName, schemaName, tableName := normalizeTable(m.resolveTableName(value, stmt))
+
+		var rows []struct {
+			IndexName   string
+			IsUnique    bool
+			IsPrimary   bool
+			Expressions string
+		}
+		err := m.DB.Raw(`SELECT index_name, is_unique, is_primary, expressions FROM duckdb_indexes()
+			WHERE database_name = COALESCE(NULLIF(?, ''), current_database())
+				AND schema_name = COALESCE(NULLIF(?, ''), current_schema())
+				AND lower(table_name) = lower(?)
+			ORDER BY index_name`, catalogName, schemaName, tableName).Scan(&rows).Error
+		if err != nil {
+			return fmt.Errorf("failed to read indexes of %s: %w", tableName, err)
+		}
+		for _, row := range rows {
+			columns, err := indexColumns(row.Expressions)
+			if err != nil {
+				return fmt.Errorf("failed to read columns of index %s: %w", row.IndexName, err)
+			}
+			indexes = append(indexes, DuckDBIndex{
+				TableName:   tableName,
+				IndexName:   row.IndexName,
+				ColumnNames: columns,
+				IsUnique:    row.IsUnique,
+				IsPrimary:   row.IsPrimary,
+			})
+		}
+
+		var constraints []struct {
+			ConstraintName        string
+			ConstraintType        string
+			ConstraintColumnNames StringArray
+		}
+		err = m.DB.Raw(`SELECT constraint_name, constraint_type, constraint_column_names FROM duckdb_constraints()
+			WHERE database_name = COALESCE(NULLIF(?, ''), current_database())
+				AND schema_name = COALESCE(NULLIF(?, ''), current_schema())
+				AND lower(table_name) = lower(?) AND constraint_type IN ('PRIMARY KEY', 'UNIQUE')
+			ORDER BY constraint_type, constraint_name`, catalogName, schemaName, tableName).Scan(&constraints).Error
+		if err != nil {
+			return fmt.Errorf("failed to read constraints of %s: %w", tableName, err)
+		}
+		for _, constraint := range constraints {
+			indexes = append(indexes, DuckDBIndex{
+				TableName:   tableName,
+				IndexName:   constraint.ConstraintName,
+				ColumnNames: constraint.ConstraintColumnNames,
+				IsUnique:    true,
+				IsPrimary:   constraint.ConstraintType == "PRIMARY KEY",
+			})
+		}
 		return nil
 	})
 
 	return indexes, err
 }
 
+// foreignKeyClause renders constraint as a FOREIGN KEY table constraint.
+// DuckDB ignores constraint names and rejects CASCADE, SET NULL and SET
+// DEFAULT, so the name and the OnDelete/OnUpdate actions are left out and
+// referenced rows can only be removed once nothing refers to them.
+func foreignKeyClause(stmt *gorm.Statement, constraint *schema.Constraint) string {
+	columns := make([]string, len(constraint.ForeignKeys))
+	for i, field := range constraint.ForeignKeys {
+		columns[i] = stmt.Quote(field.DBName)
+	}
+	references := make([]string, len(constraint.References))
+	for i, field := range constraint.References {
+		references[i] = stmt.Quote(field.DBName)
+	}
+	return fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)", strings.Join(columns, ","),
+		stmt.Quote(constraint.ReferenceSchema.Table), strings.Join(references, ","))
+}
+
+// indexColumns reads the expressions column of duckdb_indexes(), a list
+// printed as text such as [a, '"e mail"'], into column names without quotes
+func indexColumns(expressions string) ([]string, error) {
+	elements, err := splitListLiteral(expressions)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, len(elements))
+	for i, element := range elements {
+		column := element.text
+		if len(column) >= 2 && strings.HasPrefix(column, `"`) && strings.HasSuffix(column, `"`) {
+			column = strings.ReplaceAll(column[1:len(column)-1], `""`, `"`)
+		}
+		columns[i] = column
+	}
+	return columns, nil
+}
+
 // BuildIndexOptions builds index options for DuckDB
 func (m Migrator) BuildIndexOptions(opts []schema.IndexOption, stmt *gorm.Statement) (results []interface{}) {
 	for _, opt := range opts {
@@ -782,28 +1650,118 @@ func (m Migrator) BuildIndexOptions(opts []schema.IndexOption, stmt *gorm.Statem
 	return
 }
 
+// createEnumTypes creates the ENUM types that fields use, unless they exist,
+// and returns their names by column. A field tagged enum:a,b,c defines its type
+// through tags; an untagged ENUMType field takes the Name and Values of its
+// value in the migrated model, as in
+//
+//	db.AutoMigrate(&Ticket{Status: duckdb.NewEnum("ticket_status", []string{"open", "closed"}, "")})
+func (m Migrator) createEnumTypes(stmt *gorm.Statement, value interface{}, fields []*schema.Field) (map[string]string, error) {
+	names := map[string]string{}
+	for _, field := range fields {
+		name, values, ok := enumDefinition(field)
+		if !ok && field.IndirectFieldType == reflect.TypeOf(ENUMType{}) && !isTypeTagged(field) {
+			if name, values, ok = enumValueDefinition(stmt, value, field); !ok {
+				return nil, fmt.Errorf("enum field %s needs an enum tag or a model value holding its values", field.Name)
+			}
+		}
+		if !ok {
+			continue
+		}
+		names[field.DBName] = name
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = quoteLiteral(v)
+		}
+		createTypeSQL := fmt.Sprintf("CREATE TYPE IF NOT EXISTS %s AS ENUM (%s)",
+			stmt.Quote(name), strings.Join(quoted, ", "))
+		if _, err := m.DB.Statement.ConnPool.ExecContext(m.DB.Statement.Context, createTypeSQL); err != nil {
+			return nil, fmt.Errorf("failed to create enum type %s: %w", name, err)
+		}
+	}
+	return names, nil
+}
+
+// isEnumField reports fields stored in an ENUM column
+func isEnumField(field *schema.Field) bool {
+	_, _, tagged := enumDefinition(field)
+	return tagged || field.IndirectFieldType == reflect.TypeOf(ENUMType{})
+}
+
+// enumColumnType reports an ENUM column under its type name
+type enumColumnType struct {
+	gormColumnType
+	name string
+}
+
+// gormColumnType aliases gorm.ColumnType for embedding, as sqlColumnType does
+type gormColumnType = gorm.ColumnType
+
+func (c enumColumnType) DatabaseTypeName() string {
+	return c.name
+}
+
+// isTypeTagged reports a type tag naming an existing type for an ENUMType field
+func isTypeTagged(field *schema.Field) bool {
+	return field.DataType != "" && !strings.EqualFold(string(field.DataType), "ENUM")
+}
+
+// enumValueDefinition reads the ENUM type of an ENUMType field from its value
+// in the model being migrated
+func enumValueDefinition(stmt *gorm.Statement, value interface{}, field *schema.Field) (string, []string, bool) {
+	rv := reflect.Indirect(reflect.ValueOf(value))
+	if rv.Kind() != reflect.Struct || rv.Type() != stmt.Schema.ModelType {
+		return "", nil, false
+	}
+	fieldValue, _ := field.ValueOf(stmt.Context, rv)
+	var enum ENUMType
+	switch v := fieldValue.(type) {
+	case ENUMType:
+		enum = v
+	case *ENUMType:
+		if v == nil {
+			return "", nil, false
+		}
+		enum = *v
+	}
+	if len(enum.Values) == 0 {
+		return "", nil, false
+	}
+	return enumTypeName(field, enum.Name), enum.Values, true
+}
+
 // CreateTable overrides the default CreateTable to handle DuckDB-specific auto-increment sequences
 func (m Migrator) CreateTable(values ...interface{}) error {
+	defer m.catalog.reset()
+
 	for _, value := range values {
 		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
 
-			// Get the underlying SQL database connection
-			sqlDB, err := m.DB.DB()
-			if err != nil {
-				return fmt.Errorf("failed to get underlying database: %w", err)
+			// Execute through the statement's pool so a surrounding transaction applies
+			conn, ctx := m.DB.Statement.ConnPool, m.DB.Statement.Context
+
+			// Step 0: Create ENUM types used by the table's columns
+			var enums map[string]string
+			if stmt.Schema != nil {
+				var err error
+				if enums, err = m.createEnumTypes(stmt, value, stmt.Schema.Fields); err != nil {
+					return err
+				}
 			}
 
 			// Step 1: Create sequences for auto-increment fields
 			if stmt.Schema != nil {
 				for _, field := range stmt.Schema.Fields {
 					if field.PrimaryKey && (field.AutoIncrement || (!field.HasDefaultValue && field.DataType == schema.Uint)) {
-						sequenceName := "seq_" + strings.ToLower(stmt.Schema.Table) + "_" + strings.ToLower(field.DBName)
-						createSeqSQL := fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s START 1", sequenceName)
-						_, err := sqlDB.Exec(createSeqSQL)
+						sequence := sequenceName(stmt.Schema.Table, field.DBName)
+						createSeqSQL, err := createSequenceSQL(sequence, field)
 						if err != nil {
+							return err
+						}
+						if _, err := conn.ExecContext(ctx, createSeqSQL); err != nil {
 							// Ignore "already exists" errors
 							if !isAlreadyExistsError(err) {
-								return fmt.Errorf("failed to create sequence %s: %w", sequenceName, err)
+								return fmt.Errorf("failed to create sequence %s: %w", sequence, err)
 							}
 						}
 					}
@@ -820,10 +1778,18 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 			var primaryKeys []string
 
 			for _, field := range stmt.Schema.Fields {
+				// Association fields have no column
+				if field.DBName == "" || field.IgnoreMigration {
+					continue
+				}
 				columnDef := fmt.Sprintf(`"%s"`, field.DBName)
 
 				// Add data type
-				columnDef += " " + m.Dialector.DataTypeOf(field)
+				if enum, ok := enums[field.DBName]; ok {
+					columnDef += " " + enum
+				} else {
+					columnDef += " " + m.DataTypeOf(field)
+				}
 
 				// Add constraints
 				if field.NotNull {
@@ -838,29 +1804,53 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 
 				// Handle auto-increment by setting default to nextval
 				if field.PrimaryKey && (field.AutoIncrement || (!field.HasDefaultValue && field.DataType == schema.Uint)) {
-					sequenceName := "seq_" + strings.ToLower(stmt.Schema.Table) + "_" + strings.ToLower(field.DBName)
-					columnDef += fmt.Sprintf(" DEFAULT nextval('%s')", sequenceName)
+					columnDef += " DEFAULT nextval(" + quoteLiteral(sequenceName(stmt.Schema.Table, field.DBName)) + ")"
 				}
 
 				columns = append(columns, columnDef)
 			}
 
 			// Build CREATE TABLE statement
-			createSQL := fmt.Sprintf(`CREATE TABLE "%s" (%s`, tableName, strings.Join(columns, ","))
+			createSQL := fmt.Sprintf(`CREATE TABLE %s (%s`, quoteQualifiedName(tableName), strings.Join(columns, ","))
 
 			// Add primary key constraint
 			if len(primaryKeys) > 0 {
 				createSQL += fmt.Sprintf(",PRIMARY KEY (%s)", strings.Join(primaryKeys, ","))
 			}
 
+			// Add foreign keys; DuckDB has no ALTER TABLE ADD CONSTRAINT, so they
+			// can only be declared here
+			if m.createsForeignKeys() {
+				for _, rel := range stmt.Schema.Relationships.Relations {
+					if rel.Field.IgnoreMigration {
+						continue
+					}
+					if constraint := rel.ParseConstraint(); constraint != nil && constraint.Schema == stmt.Schema {
+						createSQL += "," + foreignKeyClause(stmt, constraint)
+					}
+				}
+			}
+
 			createSQL += ")"
 
-			// Step 3: Execute CREATE TABLE using the underlying SQL connection
-			_, err = sqlDB.Exec(createSQL)
-			if err != nil {
+			// Step 3: Execute CREATE TABLE
+			if _, err := conn.ExecContext(ctx, createSQL); err != nil {
 				return fmt.Errorf("failed to create table %s: %w", tableName, err)
 			}
 
+			// Step 4: Comment on the table and its columns
+			if err := m.setComments(stmt, tableName, stmt.Schema.Fields); err != nil {
+				return err
+			}
+
+			// Step 5: Create indexes declared on the model (index/uniqueIndex tags).
+			// DuckDB has no inline INDEX clause, so they are created after the table.
+			for _, idx := range stmt.Schema.ParseIndexes() {
+				if err := m.CreateIndex(value, idx.Name); err != nil {
+					return fmt.Errorf("failed to create index %s: %w", idx.Name, err)
+				}
+			}
+
 			return nil
 		}); err != nil {
 			return fmt.Errorf("failed to create table for value: %w", err)
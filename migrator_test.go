@@ -1,11 +1,16 @@
 package duckdb_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
 
 	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
 )
@@ -66,6 +71,76 @@ func TestMigrator_HasTable(t *testing.T) {
 	assert.False(t, hasTable)
 }
 
+func TestMigrator_QualifiedTableNames(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE ledger (id INTEGER PRIMARY KEY, amount DOUBLE)").Error)
+	require.NoError(t, db.Exec("CREATE SCHEMA audit").Error)
+	require.NoError(t, db.Exec("CREATE TABLE audit.ledger (id INTEGER, note VARCHAR)").Error)
+	require.NoError(t, duckdb.Attach(db, ":memory:", "archive", false))
+	require.NoError(t, db.Exec("CREATE TABLE archive.main.ledger (id INTEGER, year INTEGER, total DOUBLE)").Error)
+	require.NoError(t, db.Exec("CREATE INDEX idx_ledger_year ON archive.main.ledger (year)").Error)
+
+	columnNames := func(table string) []string {
+		columnTypes, err := migrator.ColumnTypes(table)
+		require.NoError(t, err)
+		names := make([]string, len(columnTypes))
+		for i, column := range columnTypes {
+			names[i] = column.Name()
+		}
+		return names
+	}
+
+	for _, table := range []string{"ledger", `"ledger"`} {
+		assert.True(t, migrator.HasTable(table), table)
+		assert.True(t, migrator.HasColumn(table, "amount"), table)
+	}
+
+	for _, table := range []string{"audit.ledger", `"audit"."ledger"`, `"audit".ledger`} {
+		assert.True(t, migrator.HasTable(table), table)
+		assert.True(t, migrator.HasColumn(table, "note"), table)
+		assert.False(t, migrator.HasColumn(table, "amount"), table)
+		assert.Equal(t, []string{"id", "note"}, columnNames(table), table)
+	}
+
+	for _, table := range []string{"archive.main.ledger", `"archive"."main"."ledger"`, "`archive`.`main`.`ledger`"} {
+		assert.True(t, migrator.HasTable(table), table)
+		assert.True(t, migrator.HasColumn(table, "year"), table)
+		assert.False(t, migrator.HasColumn(table, "note"), table)
+		assert.True(t, migrator.HasIndex(table, "idx_ledger_year"), table)
+		assert.Equal(t, []string{"id", "year", "total"}, columnNames(table), table)
+	}
+
+	assert.False(t, migrator.HasTable("archive.audit.ledger"))
+	assert.False(t, migrator.HasTable("missing.main.ledger"))
+	assert.False(t, migrator.HasIndex("ledger", "idx_ledger_year"), "the index belongs to the attached table")
+}
+
+func TestMigrator_AttachedDatabaseTables(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+	require.NoError(t, duckdb.Attach(db, ":memory:", "analytics", false))
+
+	type AttachedMetric struct {
+		ID   uint `gorm:"primaryKey"`
+		Name string
+	}
+
+	table := "analytics.main.attached_metrics"
+	require.NoError(t, db.Table(table).AutoMigrate(&AttachedMetric{}))
+	assert.True(t, migrator.HasTable(table))
+	assert.False(t, migrator.HasTable(migrator.CurrentDatabase()+".main.attached_metrics"),
+		"the table is created in the attached database")
+
+	// The auto-increment sequence is created next to the table
+	require.NoError(t, db.Table(table).Create(&AttachedMetric{Name: "cpu"}).Error)
+	var names []string
+	require.NoError(t, db.Table(table).Pluck("name", &names).Error)
+	assert.Equal(t, []string{"cpu"}, names)
+
+	indexes, err := migrator.GetIndexes(table)
+	require.NoError(t, err)
+	assert.NotEmpty(t, indexes, "the primary key of the attached table")
+}
+
 func TestMigrator_CreateTable(t *testing.T) {
 	_, migrator := setupMigratorTestDB(t)
 
@@ -84,6 +159,43 @@ func TestMigrator_CreateTable(t *testing.T) {
 	assert.True(t, hasTable)
 }
 
+func TestMigrator_CreateTableSkipsNonColumns(t *testing.T) {
+	_, migrator := setupMigratorTestDB(t)
+
+	type SkipTestItem struct {
+		ID      uint `gorm:"primaryKey"`
+		OwnerID uint
+	}
+	type SkipTestOwner struct {
+		ID      uint `gorm:"primaryKey"`
+		Name    string
+		Items   []SkipTestItem `gorm:"foreignKey:OwnerID"`
+		Scratch string         `gorm:"-:migration"`
+	}
+
+	require.NoError(t, migrator.CreateTable(&SkipTestOwner{}))
+
+	assert.True(t, migrator.HasColumn(&SkipTestOwner{}, "name"))
+	assert.False(t, migrator.HasColumn(&SkipTestOwner{}, "scratch"))
+}
+
+func TestMigrator_CreateTableIndexes(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	type IndexedTestTable struct {
+		ID    uint   `gorm:"primaryKey"`
+		Code  string `gorm:"uniqueIndex:idx_indexed_code"`
+		Label string `gorm:"index"`
+	}
+
+	require.NoError(t, migrator.CreateTable(&IndexedTestTable{}))
+
+	var indexes []string
+	err := db.Raw("SELECT index_name FROM duckdb_indexes() WHERE table_name = ? ORDER BY index_name", "indexed_test_tables").Scan(&indexes).Error
+	require.NoError(t, err)
+	assert.Equal(t, []string{"idx_indexed_code", "idx_indexed_test_tables_label"}, indexes)
+}
+
 func TestMigrator_DropTable(t *testing.T) {
 	db, migrator := setupMigratorTestDB(t)
 
@@ -233,15 +345,21 @@ func TestMigrator_HasIndex(t *testing.T) {
 	require.NoError(t, err)
 
 	// Check for the email index that should be created by uniqueIndex:idx_email tag
-	// Note: DuckDB index detection might vary
-	hasIndex := migrator.HasIndex(&TestUser{}, "idx_email")
-	// For now, just test that the method doesn't panic
-	// Index detection in DuckDB might work differently
-	t.Logf("HasIndex result for idx_email: %v", hasIndex)
+	assert.True(t, migrator.HasIndex(&TestUser{}, "idx_email"))
+	// Fields resolve to the index their tag names
+	assert.True(t, migrator.HasIndex(&TestUser{}, "Email"))
 
 	// Check for non-existent index
-	hasIndex = migrator.HasIndex(&TestUser{}, "non_existent_index")
+	hasIndex := migrator.HasIndex(&TestUser{}, "non_existent_index")
 	assert.False(t, hasIndex)
+
+	// An index on a table of the same name in another schema is not matched
+	require.NoError(t, db.Exec("CREATE SCHEMA archive").Error)
+	require.NoError(t, db.Exec("CREATE TABLE archive.test_users (id BIGINT, email VARCHAR)").Error)
+	require.NoError(t, db.Exec("CREATE INDEX idx_archive_email ON archive.test_users (email)").Error)
+	assert.True(t, migrator.HasIndex("archive.test_users", "idx_archive_email"))
+	assert.False(t, migrator.HasIndex("archive.test_users", "idx_email"))
+	assert.False(t, migrator.HasIndex(&TestUser{}, "idx_archive_email"))
 }
 
 func TestMigrator_CreateIndex(t *testing.T) {
@@ -302,13 +420,24 @@ func TestMigrator_DropIndex(t *testing.T) {
 }
 
 func TestMigrator_CurrentDatabase(t *testing.T) {
-	_, migrator := setupMigratorTestDB(t)
+	db, migrator := setupMigratorTestDB(t)
+
+	// In-memory databases are cataloged as "memory"
+	assert.Equal(t, "memory", migrator.CurrentDatabase())
+	assert.Equal(t, "main", migrator.CurrentSchema())
 
-	// Get current database name
-	dbName := migrator.CurrentDatabase()
-	// For in-memory database, this might be empty or a special value
-	// We just test that the method doesn't panic
-	assert.IsType(t, "", dbName)
+	require.NoError(t, duckdb.Attach(db, ":memory:", "reporting", false))
+	require.NoError(t, db.Exec("CREATE SCHEMA reporting.staging").Error)
+	require.NoError(t, db.Exec("USE reporting").Error)
+	assert.Equal(t, "reporting", migrator.CurrentDatabase())
+	assert.Equal(t, "main", migrator.CurrentSchema())
+
+	require.NoError(t, db.Exec("USE reporting.staging").Error)
+	assert.Equal(t, "reporting", migrator.CurrentDatabase())
+	assert.Equal(t, "staging", migrator.CurrentSchema())
+
+	require.NoError(t, db.Exec("USE memory").Error)
+	assert.Equal(t, "memory", migrator.CurrentDatabase())
 }
 
 func TestMigrator_GetTables(t *testing.T) {
@@ -438,3 +567,797 @@ func TestMigrator_GetTypeAliases(t *testing.T) {
 	}
 	// The main test is that the method doesn't panic
 }
+
+func TestMigrator_SchemaSnapshot(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	err := db.AutoMigrate(&TestUser{}, &MigrationTestPost{})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE VIEW test_user_names AS SELECT name FROM test_users").Error)
+
+	snapshot, err := migrator.SchemaSnapshot()
+	require.NoError(t, err)
+
+	// Views are not part of the snapshot
+	assert.Len(t, snapshot, 2)
+	assert.NotContains(t, snapshot, "test_user_names")
+
+	columnNames := func(table string) []string {
+		var names []string
+		for _, ct := range snapshot[table] {
+			names = append(names, ct.Name())
+		}
+		return names
+	}
+	assert.Equal(t, []string{"id", "name", "email", "age", "active"}, columnNames("test_users"))
+	assert.Equal(t, []string{"id", "title", "content", "user_id"}, columnNames("migration_test_posts"))
+
+	for _, ct := range snapshot["test_users"] {
+		switch ct.Name() {
+		case "id":
+			pk, ok := ct.PrimaryKey()
+			assert.True(t, ok)
+			assert.True(t, pk)
+		case "name":
+			assert.Equal(t, "VARCHAR", ct.DatabaseTypeName())
+			pk, _ := ct.PrimaryKey()
+			assert.False(t, pk)
+		case "age":
+			assert.Equal(t, "BIGINT", ct.DatabaseTypeName())
+		}
+		// Metadata without a value reports ok=false rather than panicking
+		ct.DecimalSize()
+		ct.Length()
+		ct.Comment()
+	}
+}
+
+type EnumKeyedMood struct {
+	Mood  duckdb.ENUMType `gorm:"primaryKey;type:mood_kind;enum:happy,sad,it's ok"`
+	Label string
+	Level string `gorm:"enum:low,high"`
+}
+
+func TestMigrator_EnumPrimaryKey(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	// The enum types are created before the table that uses them
+	require.NoError(t, db.AutoMigrate(&EnumKeyedMood{}))
+	require.NoError(t, db.AutoMigrate(&EnumKeyedMood{}), "re-running AutoMigrate should be a no-op")
+	assert.True(t, migrator.HasTable(&EnumKeyedMood{}))
+
+	var enumTypes []string
+	require.NoError(t, db.Raw("SELECT type_name FROM duckdb_types() WHERE logical_type = 'ENUM' AND NOT internal ORDER BY type_name").Scan(&enumTypes).Error)
+	assert.Equal(t, []string{"enum_keyed_moods_level", "mood_kind"}, enumTypes)
+
+	values := []string{"happy", "sad", "it's ok"}
+	for _, mood := range []string{"happy", "it's ok"} {
+		row := EnumKeyedMood{Mood: duckdb.NewEnum("mood_kind", values, mood), Label: "label " + mood, Level: "low"}
+		require.NoError(t, db.Create(&row).Error)
+	}
+
+	var found EnumKeyedMood
+	require.NoError(t, db.First(&found, "mood = ?", "it's ok").Error)
+	assert.Equal(t, "it's ok", found.Mood.Selected)
+	assert.Equal(t, "label it's ok", found.Label)
+
+	// The key is a plain enum column: no sequence default, duplicates rejected
+	dup := EnumKeyedMood{Mood: duckdb.NewEnum("mood_kind", values, "happy"), Label: "again", Level: "high"}
+	assert.Error(t, db.Create(&dup).Error)
+
+	bad := EnumKeyedMood{Mood: duckdb.NewEnum("mood_kind", values, "sad"), Label: "x", Level: "medium"}
+	assert.Error(t, db.Create(&bad).Error, "values outside the enum are rejected by DuckDB")
+
+	var sequences int64
+	require.NoError(t, db.Raw("SELECT COUNT(*) FROM duckdb_sequences() WHERE sequence_name LIKE 'seq_enum_keyed_moods%'").Scan(&sequences).Error)
+	assert.Zero(t, sequences)
+}
+
+type EnumTicket struct {
+	ID       uint `gorm:"primaryKey"`
+	Status   duckdb.ENUMType
+	Priority duckdb.ENUMType
+}
+
+func TestMigrator_EnumTypeFromModel(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	statuses := []string{"open", "closed"}
+	model := &EnumTicket{
+		Status:   duckdb.NewEnum("ticket_status", statuses, ""),
+		Priority: duckdb.NewEnum("", []string{"low", "high"}, ""),
+	}
+	require.NoError(t, db.AutoMigrate(model))
+	require.NoError(t, db.AutoMigrate(model), "re-running AutoMigrate should be a no-op")
+
+	var enumTypes []string
+	require.NoError(t, db.Raw("SELECT type_name FROM duckdb_types() WHERE logical_type = 'ENUM' AND NOT internal ORDER BY type_name").Scan(&enumTypes).Error)
+	assert.Equal(t, []string{"enum_tickets_priority", "ticket_status"}, enumTypes)
+
+	ticket := EnumTicket{
+		Status:   duckdb.NewEnum("ticket_status", statuses, "open"),
+		Priority: duckdb.NewEnum("", []string{"low", "high"}, "high"),
+	}
+	require.NoError(t, db.Create(&ticket).Error)
+
+	var found EnumTicket
+	require.NoError(t, db.First(&found, ticket.ID).Error)
+	assert.Equal(t, "open", found.Status.Selected)
+	assert.Equal(t, "high", found.Priority.Selected)
+
+	assert.Error(t, db.Exec("INSERT INTO enum_tickets (id, status) VALUES (99, 'pending')").Error,
+		"values outside the enum are rejected by DuckDB")
+
+	// Without values there is no type to create
+	require.NoError(t, migrator.DropTable(&EnumTicket{}))
+	assert.Error(t, db.AutoMigrate(&EnumTicket{}))
+}
+
+type CTASReading struct {
+	ID    uint `gorm:"primaryKey;autoIncrement:false"`
+	Label string
+}
+
+func TestMigrator_AddPrimaryKey(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	require.NoError(t, db.Exec("CREATE TABLE ctas_readings AS SELECT * FROM (VALUES (1, 'a'), (2, 'b')) v(id, label)").Error)
+	assert.False(t, migrator.HasConstraint(&CTASReading{}, "ctas_readings_id_pkey"))
+
+	// Duplicate keys can still be inserted before the primary key exists
+	require.NoError(t, db.Exec("INSERT INTO ctas_readings VALUES (2, 'dup')").Error)
+	err := migrator.AddPrimaryKey(&CTASReading{})
+	assert.Error(t, err, "existing duplicates should block the primary key")
+
+	require.NoError(t, db.Exec("DELETE FROM ctas_readings WHERE label = 'dup'").Error)
+	require.NoError(t, migrator.AddPrimaryKey(&CTASReading{}, "ID"))
+	assert.True(t, migrator.HasConstraint(&CTASReading{}, "ctas_readings_id_pkey"))
+
+	err = db.Create(&CTASReading{ID: 1, Label: "again"}).Error
+	require.Error(t, err, "duplicate keys are rejected")
+	assert.Contains(t, err.Error(), "primary key")
+	require.NoError(t, db.Create(&CTASReading{ID: 3, Label: "c"}).Error)
+
+	err = migrator.AddPrimaryKey(&CTASReading{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already has a primary key")
+}
+
+func TestMigrator_AddPrimaryKeySchemaScoped(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	// Same-named tables in two schemas, only one of each pair keyed
+	require.NoError(t, db.Exec("CREATE SCHEMA staging").Error)
+	require.NoError(t, db.Exec("CREATE TABLE shadow_readings (id INTEGER, label VARCHAR)").Error)
+	require.NoError(t, db.Exec("CREATE TABLE staging.shadow_readings (id INTEGER PRIMARY KEY, label VARCHAR)").Error)
+	require.NoError(t, db.Exec("CREATE TABLE shadow_labels (id INTEGER PRIMARY KEY, label VARCHAR)").Error)
+	require.NoError(t, db.Exec("CREATE TABLE staging.shadow_labels (id INTEGER, label VARCHAR)").Error)
+
+	require.NoError(t, migrator.AddPrimaryKey("shadow_readings", "id"))
+	require.NoError(t, migrator.AddPrimaryKey("staging.shadow_labels", "id"))
+	assert.Error(t, db.Exec("INSERT INTO staging.shadow_labels VALUES (1, 'a'), (1, 'b')").Error)
+
+	err := migrator.AddPrimaryKey("staging.shadow_readings", "id")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already has a primary key")
+}
+
+func TestMigrator_HasColumnHasIndexCached(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&TestUser{}))
+
+	for _, name := range []string{"ID", "name", "Email", "AGE", "active"} {
+		assert.True(t, migrator.HasColumn(&TestUser{}, name), name)
+	}
+	assert.False(t, migrator.HasColumn(&TestUser{}, "nickname"))
+	assert.False(t, migrator.HasColumn("missing_table", "name"))
+
+	assert.True(t, migrator.HasIndex(&TestUser{}, "idx_email"))
+	assert.True(t, migrator.HasIndex("test_users", "IDX_EMAIL"))
+	assert.False(t, migrator.HasIndex(&TestUser{}, "idx_missing"))
+
+	// Schema changes through the migrator are visible immediately. DuckDB cannot
+	// drop columns from indexed tables, so use one without indexes for that.
+	type CachedNote struct {
+		ID   uint
+		Body string
+		Tag  string
+	}
+	require.NoError(t, migrator.AutoMigrate(&CachedNote{}))
+	assert.True(t, migrator.HasColumn(&CachedNote{}, "tag"))
+	require.NoError(t, migrator.DropColumn(&CachedNote{}, "Tag"))
+	assert.False(t, migrator.HasColumn(&CachedNote{}, "tag"))
+	require.NoError(t, migrator.AddColumn(&CachedNote{}, "Tag"))
+	assert.True(t, migrator.HasColumn(&CachedNote{}, "tag"))
+
+	require.NoError(t, migrator.DropIndex(&TestUser{}, "idx_email"))
+	assert.False(t, migrator.HasIndex(&TestUser{}, "idx_email"))
+	require.NoError(t, migrator.CreateIndex(&TestUser{}, "idx_email"))
+	assert.True(t, migrator.HasIndex(&TestUser{}, "idx_email"))
+
+	// A fresh migrator sees changes made outside it
+	require.NoError(t, db.Exec("ALTER TABLE test_users ADD COLUMN nickname VARCHAR").Error)
+	assert.True(t, db.Migrator().HasColumn(&TestUser{}, "nickname"))
+}
+
+func BenchmarkMigrator_HasColumnWideTable(b *testing.B) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(b, err)
+
+	columns := make([]string, 200)
+	for i := range columns {
+		columns[i] = fmt.Sprintf("c%d INTEGER", i)
+	}
+	require.NoError(b, db.Exec("CREATE TABLE wide_metrics ("+strings.Join(columns, ", ")+")").Error)
+
+	migrator := db.Migrator()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for c := 0; c < 200; c += 10 {
+			if !migrator.HasColumn("wide_metrics", fmt.Sprintf("c%d", c)) {
+				b.Fatalf("column c%d not found", c)
+			}
+		}
+	}
+}
+
+func TestMigrator_CreateTableFromColumns(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	specs := []duckdb.ColumnSpec{
+		{Name: "id", Type: "INTEGER", PrimaryKey: true, AutoIncrement: true},
+		{Name: "label", Type: "VARCHAR", NotNull: true},
+		{Name: "status", Type: "VARCHAR", Default: "it's new"},
+		{Name: "score", Type: "DECIMAL(10,2)", Default: 1.5},
+		{Name: "tags", Type: "VARCHAR[]"},
+		{Name: "created at", Type: "TIMESTAMP", Default: clause.Expr{SQL: "current_timestamp"}},
+	}
+	require.NoError(t, migrator.CreateTableFromColumns("dynamic_items", specs))
+	assert.True(t, migrator.HasTable("dynamic_items"))
+	assert.True(t, migrator.HasColumn("dynamic_items", "created at"))
+	assert.True(t, migrator.HasConstraint("dynamic_items", "dynamic_items_id_pkey"))
+
+	require.NoError(t, db.Exec(`INSERT INTO dynamic_items (label, tags) VALUES (?, ?), (?, ?)`,
+		"first", duckdb.StringArray{"a"}, "second", duckdb.StringArray{"b", "c"}).Error)
+
+	type dynamicItem struct {
+		ID        int
+		Label     string
+		Status    string
+		Score     float64
+		Tags      duckdb.StringArray
+		CreatedAt time.Time `gorm:"column:created at"`
+	}
+	var items []dynamicItem
+	require.NoError(t, db.Table("dynamic_items").Order("id").Find(&items).Error)
+	require.Len(t, items, 2)
+	assert.Equal(t, 1, items[0].ID)
+	assert.Equal(t, 2, items[1].ID)
+	assert.Equal(t, "it's new", items[0].Status)
+	assert.InDelta(t, 1.5, items[0].Score, 1e-9)
+	assert.Equal(t, duckdb.StringArray{"b", "c"}, items[1].Tags)
+	assert.False(t, items[0].CreatedAt.IsZero())
+
+	assert.Error(t, db.Exec("INSERT INTO dynamic_items (label) VALUES (NULL)").Error, "NOT NULL is enforced")
+	assert.Error(t, db.Exec("INSERT INTO dynamic_items (id, label) VALUES (1, 'dup')").Error, "primary key is enforced")
+
+	// Specs built from untrusted input cannot smuggle SQL through the type
+	for _, columnType := range []string{
+		"INTEGER); DROP TABLE dynamic_items; --",
+		"INTEGER, injected VARCHAR",
+		"INTEGER CHECK (x > 0)",
+		"INTEGER DEFAULT (1)",
+		"VARCHAR(current_user)",
+		"DECIMAL(10,2,3)",
+	} {
+		err := migrator.CreateTableFromColumns("evil", []duckdb.ColumnSpec{{Name: "x", Type: columnType}})
+		require.Error(t, err, columnType)
+	}
+	assert.False(t, migrator.HasTable("evil"))
+	assert.True(t, migrator.HasTable("dynamic_items"))
+
+	// Names are quoted rather than interpreted
+	require.NoError(t, migrator.CreateTableFromColumns(`odd"name`, []duckdb.ColumnSpec{{Name: `a"b`, Type: "INTEGER"}}))
+	var column string
+	require.NoError(t, db.Raw(`SELECT column_name FROM duckdb_columns() WHERE table_name = 'odd"name'`).Scan(&column).Error)
+	assert.Equal(t, `a"b`, column)
+}
+
+func TestMigrator_StorageInfo(t *testing.T) {
+	db, m := setupMigratorTestDB(t)
+
+	type Metric struct {
+		ID    uint `gorm:"primaryKey"`
+		Name  string
+		Value float64
+	}
+	require.NoError(t, db.AutoMigrate(&Metric{}))
+	require.NoError(t, db.Exec("INSERT INTO metrics SELECT range, 'm' || (range % 7), range / 3 FROM range(1, 5001)").Error)
+
+	stats, err := m.StorageInfo(&Metric{})
+	require.NoError(t, err)
+	require.NotEmpty(t, stats)
+
+	rows := map[string]int64{}
+	for _, stat := range stats {
+		assert.NotEmpty(t, stat.Compression)
+		assert.NotEmpty(t, stat.SegmentType)
+		if stat.SegmentType != "VALIDITY" {
+			rows[stat.ColumnName] += stat.Count
+		}
+	}
+	assert.Equal(t, map[string]int64{"id": 5000, "name": 5000, "value": 5000}, rows)
+
+	_, err = m.StorageInfo("missing_table")
+	assert.Error(t, err)
+}
+
+func TestMigrator_RecreateTable(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	require.NoError(t, db.AutoMigrate(&TestUser{}))
+	for _, name := range []string{"alice", "bob"} {
+		require.NoError(t, db.Create(&TestUser{Name: name, Email: name + "@example.com"}).Error)
+	}
+	require.NoError(t, db.Exec("ALTER TABLE test_users ADD COLUMN legacy INTEGER").Error)
+	require.True(t, migrator.HasColumn(&TestUser{}, "legacy"))
+
+	require.NoError(t, migrator.RecreateTable(&TestUser{}))
+
+	var count int64
+	require.NoError(t, db.Model(&TestUser{}).Count(&count).Error)
+	assert.Zero(t, count)
+	assert.False(t, migrator.HasColumn(&TestUser{}, "legacy"), "columns not in the model are gone")
+	assert.True(t, migrator.HasColumn(&TestUser{}, "Email"))
+	assert.True(t, migrator.HasIndex(&TestUser{}, "idx_email"))
+
+	// The auto-increment sequence starts over
+	user := TestUser{Name: "carol", Email: "carol@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	assert.Equal(t, uint(1), user.ID)
+
+	// Recreating a table that does not exist yet just creates it
+	require.NoError(t, migrator.RecreateTable(&MigrationTestPost{}))
+	assert.True(t, migrator.HasTable(&MigrationTestPost{}))
+}
+
+func TestMigrator_TypedMapColumns(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	type Inventory struct {
+		ID       uint           `gorm:"primaryKey"`
+		Counts   duckdb.MapType `duckdb:"map:VARCHAR,INTEGER"`
+		Prices   duckdb.MapType `duckdb:"map:INTEGER,DECIMAL(10,2)"`
+		Totals   duckdb.MapType `gorm:"type:MAP(VARCHAR, BIGINT)"`
+		Comments duckdb.MapType
+		Checked  duckdb.MapType `duckdb:"map:VARCHAR,INTEGER CHECK (true)"`
+	}
+	require.NoError(t, db.AutoMigrate(&Inventory{}))
+
+	columnTypes, err := migrator.ColumnTypes(&Inventory{})
+	require.NoError(t, err)
+	types := map[string]string{}
+	for _, column := range columnTypes {
+		types[column.Name()] = column.DatabaseTypeName()
+	}
+	assert.Equal(t, "MAP(VARCHAR, INTEGER)", types["counts"])
+	assert.Equal(t, "MAP(INTEGER, DECIMAL(10,2))", types["prices"])
+	assert.Equal(t, "MAP(VARCHAR, BIGINT)", types["totals"])
+	assert.Equal(t, "MAP(VARCHAR, VARCHAR)", types["comments"], "untagged maps keep the default")
+	assert.Equal(t, "MAP(VARCHAR, VARCHAR)", types["checked"], "anything but plain types is ignored")
+
+	inventory := Inventory{
+		Counts:   duckdb.MapType{"apples": 3, "pears": int64(0)},
+		Prices:   duckdb.MapType{"7": 1.25},
+		Totals:   duckdb.MapType{"views": int64(9000000000), "likes": 12},
+		Comments: duckdb.MapType{"note": `says "hi", then=leaves`},
+	}
+	require.NoError(t, db.Create(&inventory).Error)
+	require.NoError(t, db.Create(&Inventory{Counts: duckdb.MapType{}}).Error)
+	require.NoError(t, db.AutoMigrate(&Inventory{}), "migrating again keeps the typed columns")
+
+	var found []Inventory
+	require.NoError(t, db.Order("id").Find(&found).Error)
+	require.Len(t, found, 2)
+	assert.Equal(t, duckdb.MapType{"apples": int32(3), "pears": int32(0)}, found[0].Counts)
+	assert.Len(t, found[0].Prices, 1)
+	assert.Equal(t, duckdb.MapType{"views": int64(9000000000), "likes": int64(12)}, found[0].Totals)
+	assert.Equal(t, `says "hi", then=leaves`, found[0].Comments["note"])
+	assert.Empty(t, found[1].Counts)
+
+	var apples int
+	require.NoError(t, db.Model(&Inventory{}).Where("id = ?", inventory.ID).Select("counts['apples']").Scan(&apples).Error)
+	assert.Equal(t, 3, apples)
+
+	var views struct {
+		Next int64
+		Type string
+	}
+	require.NoError(t, db.Model(&Inventory{}).Where("id = ?", inventory.ID).
+		Select("totals['views'] + 1 AS next, typeof(totals['views']) AS type").Scan(&views).Error)
+	assert.Equal(t, int64(9000000001), views.Next)
+	assert.Equal(t, "BIGINT", views.Type)
+}
+
+func TestMigrator_DecimalColumns(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	type Ledger struct {
+		ID      uint               `gorm:"primaryKey"`
+		Amount  duckdb.DecimalType `gorm:"type:DECIMAL(10,2)"`
+		Rate    duckdb.DecimalType `gorm:"precision:12;scale:4"`
+		Balance duckdb.DecimalType
+	}
+	require.NoError(t, db.AutoMigrate(&Ledger{}))
+
+	columnTypes, err := migrator.ColumnTypes(&Ledger{})
+	require.NoError(t, err)
+	types := map[string]string{}
+	for _, column := range columnTypes {
+		types[column.Name()] = column.DatabaseTypeName()
+	}
+	assert.Equal(t, "DECIMAL(10,2)", types["amount"])
+	assert.Equal(t, "DECIMAL(12,4)", types["rate"])
+	assert.Equal(t, "DECIMAL(18,6)", types["balance"], "untagged decimals keep the default")
+	require.NoError(t, db.AutoMigrate(&Ledger{}), "migrating again keeps the decimal columns")
+
+	ledger := Ledger{
+		Amount:  duckdb.NewDecimal("12345678.90", 10, 2),
+		Rate:    duckdb.NewDecimal("0.0725", 12, 4),
+		Balance: duckdb.NewDecimal("1.5", 0, 0),
+	}
+	require.NoError(t, db.Create(&ledger).Error)
+
+	var found Ledger
+	require.NoError(t, db.First(&found, ledger.ID).Error)
+	assert.Equal(t, duckdb.NewDecimal("12345678.90", 10, 2), found.Amount)
+	assert.Equal(t, duckdb.NewDecimal("0.0725", 12, 4), found.Rate)
+	assert.Equal(t, duckdb.NewDecimal("1.500000", 18, 6), found.Balance)
+}
+
+func TestMigrator_GetIndexes(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	type IndexedAccount struct {
+		ID     uint   `gorm:"primaryKey"`
+		Email  string `gorm:"uniqueIndex"`
+		Region string `gorm:"index:idx_region_team"`
+		Team   string `gorm:"index:idx_region_team"`
+		Handle string `gorm:"unique"`
+	}
+	require.NoError(t, db.AutoMigrate(&IndexedAccount{}))
+
+	indexes, err := migrator.GetIndexes(&IndexedAccount{})
+	require.NoError(t, err)
+
+	byName := map[string]gorm.Index{}
+	for _, index := range indexes {
+		assert.Equal(t, "indexed_accounts", index.Table())
+		byName[index.Name()] = index
+	}
+
+	email, ok := byName["idx_indexed_accounts_email"]
+	require.True(t, ok, "unique index from AutoMigrate is listed: %v", byName)
+	assert.Equal(t, []string{"email"}, email.Columns())
+	unique, _ := email.Unique()
+	assert.True(t, unique)
+	primary, _ := email.PrimaryKey()
+	assert.False(t, primary)
+
+	composite, ok := byName["idx_region_team"]
+	require.True(t, ok)
+	assert.Equal(t, []string{"region", "team"}, composite.Columns())
+	unique, _ = composite.Unique()
+	assert.False(t, unique)
+
+	var primaryKey, uniqueHandle gorm.Index
+	for _, index := range indexes {
+		if isPrimary, _ := index.PrimaryKey(); isPrimary {
+			primaryKey = index
+		} else if columns := index.Columns(); len(columns) == 1 && columns[0] == "handle" {
+			uniqueHandle = index
+		}
+	}
+	require.NotNil(t, primaryKey)
+	assert.Equal(t, []string{"id"}, primaryKey.Columns())
+	require.NotNil(t, uniqueHandle, "UNIQUE column constraint is listed")
+	unique, _ = uniqueHandle.Unique()
+	assert.True(t, unique)
+
+	// Quoted and expression columns come back as written
+	require.NoError(t, db.Exec(`CREATE INDEX "idx_lower_email" ON indexed_accounts (lower(email), "Team")`).Error)
+	indexes, err = migrator.GetIndexes("indexed_accounts")
+	require.NoError(t, err)
+	var expressionColumns []string
+	for _, index := range indexes {
+		if index.Name() == "idx_lower_email" {
+			expressionColumns = index.Columns()
+		}
+	}
+	assert.Equal(t, []string{"(lower(email))", "Team"}, expressionColumns)
+}
+
+func TestMigrator_CreateIndexExpressions(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	type IndexedEvent struct {
+		ID      uint   `gorm:"primaryKey"`
+		Source  string `gorm:"index:idx_source_kind,priority:1"`
+		Kind    string `gorm:"index:idx_source_kind,priority:2"`
+		Email   string `gorm:"index:idx_email_lower,expression:lower(email)"`
+		Payload string `gorm:"index:idx_payload,type:ART"`
+	}
+	require.NoError(t, db.AutoMigrate(&IndexedEvent{}))
+	// Indexes already created with the table are skipped
+	require.NoError(t, migrator.CreateIndex(&IndexedEvent{}, "idx_source_kind"))
+
+	indexes, err := migrator.GetIndexes(&IndexedEvent{})
+	require.NoError(t, err)
+	columns := map[string][]string{}
+	for _, index := range indexes {
+		columns[index.Name()] = index.Columns()
+	}
+	assert.Equal(t, []string{"source", "kind"}, columns["idx_source_kind"])
+	assert.Equal(t, []string{"(lower(email))"}, columns["idx_email_lower"])
+	assert.Equal(t, []string{"payload"}, columns["idx_payload"])
+
+	require.NoError(t, db.Create(&IndexedEvent{Source: "web", Kind: "click", Email: "Ada@Example.com"}).Error)
+	var count int64
+	require.NoError(t, db.Model(&IndexedEvent{}).Where("lower(email) = ?", "ada@example.com").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	type PartialIndexed struct {
+		ID     uint   `gorm:"primaryKey"`
+		Status string `gorm:"index:idx_active,where:status = 'active'"`
+	}
+	err = migrator.CreateIndex(&PartialIndexed{}, "idx_active")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "partial indexes")
+	assert.Error(t, migrator.CreateIndex(&IndexedEvent{}, "idx_missing"))
+}
+
+type CommentedInvoice struct {
+	ID     uint   `gorm:"primaryKey;comment:Invoice number"`
+	Amount int64  `gorm:"comment:Amount in cents, can't be negative"`
+	Status string `gorm:"size:20;comment:draft or sent"`
+}
+
+func (CommentedInvoice) TableComment() string { return "Customer invoices" }
+
+type CommentedInvoiceV2 struct {
+	ID     uint `gorm:"primaryKey;comment:Invoice number"`
+	Amount int64
+	Status string `gorm:"size:20;comment:draft, sent or paid"`
+	Notes  string `gorm:"comment:Free-form notes"`
+}
+
+func (CommentedInvoiceV2) TableName() string { return "commented_invoices" }
+
+func TestMigrator_Comments(t *testing.T) {
+	db, _ := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CommentedInvoice{}))
+
+	columnComments := func() map[string]string {
+		var rows []struct {
+			ColumnName string
+			Comment    *string
+		}
+		require.NoError(t, db.Raw("SELECT column_name, comment FROM duckdb_columns() WHERE table_name = 'commented_invoices'").Scan(&rows).Error)
+		comments := map[string]string{}
+		for _, row := range rows {
+			if row.Comment != nil {
+				comments[row.ColumnName] = *row.Comment
+			}
+		}
+		return comments
+	}
+	assert.Equal(t, map[string]string{
+		"id":     "Invoice number",
+		"amount": "Amount in cents, can't be negative",
+		"status": "draft or sent",
+	}, columnComments())
+
+	var tableComment string
+	require.NoError(t, db.Raw("SELECT comment FROM duckdb_tables() WHERE table_name = 'commented_invoices'").Scan(&tableComment).Error)
+	assert.Equal(t, "Customer invoices", tableComment)
+
+	// Added columns get their comment and changed comments are updated
+	require.NoError(t, db.AutoMigrate(&CommentedInvoiceV2{}))
+	comments := columnComments()
+	assert.Equal(t, "Free-form notes", comments["notes"])
+	assert.Equal(t, "draft, sent or paid", comments["status"])
+}
+
+type FKUser struct {
+	ID    uint `gorm:"primaryKey"`
+	Name  string
+	Posts []FKPost `gorm:"foreignKey:UserID"`
+}
+
+type FKPost struct {
+	ID     uint   `gorm:"primaryKey"`
+	UserID uint   `gorm:"uniqueIndex:idx_fk_posts_user_slug"`
+	Slug   string `gorm:"uniqueIndex:idx_fk_posts_user_slug"`
+	User   FKUser
+}
+
+func TestMigrator_ForeignKeys(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&FKUser{}, &FKPost{}))
+
+	var foreignKeys []struct {
+		ConstraintColumnNames duckdb.StringArray
+		ReferencedTable       string
+		ReferencedColumnNames duckdb.StringArray
+	}
+	require.NoError(t, db.Raw(`SELECT constraint_column_names, referenced_table, referenced_column_names
+		FROM duckdb_constraints() WHERE table_name = 'fk_posts' AND constraint_type = 'FOREIGN KEY'`).Scan(&foreignKeys).Error)
+	require.Len(t, foreignKeys, 1)
+	assert.Equal(t, duckdb.StringArray{"user_id"}, foreignKeys[0].ConstraintColumnNames)
+	assert.Equal(t, "fk_users", foreignKeys[0].ReferencedTable)
+	assert.Equal(t, duckdb.StringArray{"id"}, foreignKeys[0].ReferencedColumnNames)
+	assert.True(t, migrator.HasConstraint(&FKUser{}, "Posts"))
+
+	// Migrating again finds the constraint instead of trying to add it
+	require.NoError(t, db.AutoMigrate(&FKUser{}, &FKPost{}))
+
+	user := FKUser{Name: "alice"}
+	require.NoError(t, db.Create(&user).Error)
+	require.NoError(t, db.Create(&FKPost{UserID: user.ID, Slug: "hello"}).Error)
+
+	err := db.Create(&FKPost{UserID: user.ID + 100, Slug: "orphan"}).Error
+	require.Error(t, err)
+	assert.True(t, duckdb.IsForeignKeyError(err))
+
+	// The composite unique index allows a slug per user but not twice
+	other := FKUser{Name: "bob"}
+	require.NoError(t, db.Create(&other).Error)
+	require.NoError(t, db.Create(&FKPost{UserID: other.ID, Slug: "hello"}).Error)
+	require.Error(t, db.Create(&FKPost{UserID: user.ID, Slug: "hello"}).Error)
+}
+
+func TestMigrator_ForeignKeyColumnTypes(t *testing.T) {
+	columnType := func(db *gorm.DB) string {
+		var dataType string
+		require.NoError(t, db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'fk_posts' AND column_name = 'user_id'").Scan(&dataType).Error)
+		return dataType
+	}
+
+	// The foreign key takes the type of the primary key it references
+	db, _ := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&FKUser{}, &FKPost{}))
+	assert.Equal(t, "INTEGER", columnType(db))
+
+	// Without constraints the column keeps the type of its own field
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&FKUser{}, &FKPost{}))
+	assert.Equal(t, "BIGINT", columnType(db))
+	require.NoError(t, db.AutoMigrate(&FKUser{}, &FKPost{}), "migrating again leaves the column alone")
+	assert.Equal(t, "BIGINT", columnType(db))
+}
+
+type ShardedOrder struct {
+	ID   uint `gorm:"primaryKey;sequence:start=1000,increment=2"`
+	Item string
+}
+
+func TestMigrator_SequenceOptions(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&ShardedOrder{}))
+
+	var sequence struct {
+		StartValue  int64
+		IncrementBy int64
+		Cycle       bool
+	}
+	require.NoError(t, db.Raw("SELECT start_value, increment_by, cycle FROM duckdb_sequences() WHERE sequence_name = 'seq_sharded_orders_id'").Scan(&sequence).Error)
+	assert.Equal(t, int64(1000), sequence.StartValue)
+	assert.Equal(t, int64(2), sequence.IncrementBy)
+	assert.False(t, sequence.Cycle)
+
+	_, err := migrator.CurrentSequenceValue(&ShardedOrder{}, "ID")
+	assert.Error(t, err, "nothing drawn yet")
+
+	orders := []ShardedOrder{{Item: "a"}, {Item: "b"}}
+	require.NoError(t, db.Create(&orders).Error)
+	third := ShardedOrder{Item: "c"}
+	require.NoError(t, db.Create(&third).Error)
+	assert.Equal(t, []uint{1000, 1002}, []uint{orders[0].ID, orders[1].ID})
+	assert.Equal(t, uint(1004), third.ID)
+
+	current, err := migrator.CurrentSequenceValue(&ShardedOrder{}, "id")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1004), current)
+
+	// The sequence outlives its table until dropped
+	require.Error(t, migrator.DropSequence(&ShardedOrder{}, "ID"), "still used by the table")
+	require.NoError(t, migrator.DropTable(&ShardedOrder{}))
+	require.NoError(t, migrator.DropSequence(&ShardedOrder{}, "ID"))
+	_, err = migrator.CurrentSequenceValue(&ShardedOrder{}, "ID")
+	assert.Error(t, err)
+}
+
+func TestMigrator_SequenceOptionsInvalid(t *testing.T) {
+	type BadSequence struct {
+		ID uint `gorm:"primaryKey;sequence:start=ten"`
+	}
+	type CachedSequence struct {
+		ID uint `gorm:"primaryKey;sequence:cache=10"`
+	}
+	db, _ := setupMigratorTestDB(t)
+	assert.ErrorContains(t, db.AutoMigrate(&BadSequence{}), `invalid sequence option "start=ten"`)
+	assert.ErrorContains(t, db.AutoMigrate(&CachedSequence{}), `unknown sequence option "cache=10"`)
+}
+
+type EvolvingGadget struct {
+	ID     uint
+	Name   string
+	Legacy string
+}
+
+func (EvolvingGadget) TableName() string { return "evolving_gadgets" }
+
+type EvolvingGadgetV2 struct {
+	ID     uint
+	Name   string
+	Color  string  `gorm:"not null;default:'red'"`
+	Count  int     `gorm:"default:3"`
+	Serial *string `gorm:"unique"`
+}
+
+func (EvolvingGadgetV2) TableName() string { return "evolving_gadgets" }
+
+func TestMigrator_AutoMigrateAddsColumns(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&EvolvingGadget{}))
+	require.NoError(t, db.Create(&EvolvingGadget{Name: "first", Legacy: "kept"}).Error)
+
+	require.NoError(t, db.AutoMigrate(&EvolvingGadgetV2{}))
+	for _, column := range []string{"color", "count", "serial"} {
+		assert.True(t, migrator.HasColumn(&EvolvingGadgetV2{}, column), column)
+	}
+	// Columns missing from the model are only dropped by DropColumn
+	assert.True(t, migrator.HasColumn(&EvolvingGadgetV2{}, "legacy"))
+
+	// Existing rows take the defaults and the constraints apply to new rows
+	var existing EvolvingGadgetV2
+	require.NoError(t, db.First(&existing).Error)
+	assert.Equal(t, "red", existing.Color)
+	assert.Equal(t, 3, existing.Count)
+	assert.Error(t, db.Exec("INSERT INTO evolving_gadgets (name, color) VALUES ('nameless', NULL)").Error)
+
+	serial := "S-1"
+	require.NoError(t, db.Create(&EvolvingGadgetV2{Name: "second", Serial: &serial}).Error)
+	assert.Error(t, db.Create(&EvolvingGadgetV2{Name: "third", Serial: &serial}).Error)
+
+	// Migrating again finds nothing to change
+	require.NoError(t, db.AutoMigrate(&EvolvingGadgetV2{}))
+}
+
+type IndexedGadget struct {
+	ID   uint
+	Name string `gorm:"index"`
+}
+
+func (IndexedGadget) TableName() string { return "indexed_gadgets" }
+
+type IndexedGadgetV2 struct {
+	ID    uint
+	Name  string `gorm:"index"`
+	Color string `gorm:"not null;default:'red'"`
+}
+
+func (IndexedGadgetV2) TableName() string { return "indexed_gadgets" }
+
+func TestMigrator_AddNotNullColumnToIndexedTable(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&IndexedGadget{}))
+
+	// DuckDB cannot set NOT NULL on a table with indexes; the error is
+	// returned and the column is not added half-way
+	err := db.AutoMigrate(&IndexedGadgetV2{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to set color not null")
+	assert.False(t, migrator.HasColumn(&IndexedGadgetV2{}, "color"))
+}
@@ -0,0 +1,861 @@
+package duckdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ===== MAP FUNCTIONS =====
+
+// MapKeys returns an expression for map_keys(column), yielding the keys of a MAP column as a LIST.
+// The result scans into StringArray, IntArray or ListType depending on the key type.
+func MapKeys(column string) clause.Expr {
+	return clause.Expr{SQL: "map_keys(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// MapValues returns an expression for map_values(column), yielding the values of a MAP column as a LIST.
+func MapValues(column string) clause.Expr {
+	return clause.Expr{SQL: "map_values(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// MapEntries returns an expression for map_entries(column), yielding a LIST of
+// STRUCT(key, value) entries. The result scans into ListType.
+func MapEntries(column string) clause.Expr {
+	return clause.Expr{SQL: "map_entries(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ===== STRUCT FUNCTIONS =====
+
+// StructStar returns an expression for column.*, expanding a STRUCT column's
+// fields into top-level result columns that scan into a flat struct. DuckDB only
+// accepts an unqualified column here, so column must not carry a table prefix.
+func StructStar(column string) clause.Expr {
+	return clause.Expr{SQL: "?.*", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ===== COLUMN UPDATES =====
+
+// ColumnsUpdate assigns a templated expression to every column whose name
+// matches Pattern. Build it with UpdateColumns and pass it to Updates.
+type ColumnsUpdate struct {
+	Pattern  string
+	Template string
+}
+
+// UpdateColumns applies exprTemplate to every model column matching the regular
+// expression pattern, mirroring DuckDB's COLUMNS(...) which UPDATE SET does not
+// accept. Each ? in exprTemplate stands for the matched column:
+//
+//	db.Model(&Price{}).Where("id > ?", 0).Updates(duckdb.UpdateColumns("^price_", "round(?, 1)"))
+func UpdateColumns(pattern, exprTemplate string) ColumnsUpdate {
+	return ColumnsUpdate{Pattern: pattern, Template: exprTemplate}
+}
+
+// assignments expands the update into per-column expressions for the model schema.
+func (u ColumnsUpdate) assignments(s *schema.Schema) (map[string]interface{}, error) {
+	re, err := regexp.Compile(u.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid column pattern %q: %w", u.Pattern, err)
+	}
+
+	values := map[string]interface{}{}
+	for _, name := range s.DBNames {
+		if !re.MatchString(name) {
+			continue
+		}
+		vars := make([]interface{}, strings.Count(u.Template, "?"))
+		for i := range vars {
+			vars[i] = clause.Column{Name: name}
+		}
+		values[name] = clause.Expr{SQL: u.Template, Vars: vars}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no columns of %s match pattern %q", s.Table, u.Pattern)
+	}
+	return values, nil
+}
+
+// ===== JSON FUNCTIONS =====
+
+// JSONMergePatch returns an update map for db.Updates that merges patch into a
+// JSON column via json_merge_patch (RFC 7396): keys in patch overwrite, nested
+// objects merge, and null values remove keys. The patch is marshaled and bound
+// as a parameter.
+//
+//	db.Model(&doc).Updates(duckdb.JSONMergePatch("payload", map[string]interface{}{"status": "done"}))
+func JSONMergePatch(column string, patch interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		column: clause.Expr{
+			SQL:  "json_merge_patch(?, ?::JSON)",
+			Vars: []interface{}{clause.Column{Name: column}, JSONType{Data: patch}},
+		},
+	}
+}
+
+// JSONExtract returns an expression for json_extract_string(column, path), the
+// value at path in a JSON column as VARCHAR (DuckDB's ->> operator), or NULL
+// when the path is missing. path is a JSONPath such as "$.user.name" or a JSON
+// pointer such as "/user/name" and is bound as a parameter:
+//
+//	db.Where(clause.Eq{Column: duckdb.JSONExtract("payload", "$.user.name"), Value: "ann"})
+//	db.Select("id, ? AS name", duckdb.JSONExtract("payload", "$.user.name"))
+func JSONExtract(column, path string) clause.Expr {
+	return clause.Expr{SQL: "json_extract_string(?, ?)", Vars: []interface{}{clause.Column{Name: column}, path}}
+}
+
+// JSONContains returns a condition that is true when the JSON column contains
+// value, marshaled to JSON and bound: an object matches when its keys and
+// values are present, an array when all its elements are.
+//
+//	db.Where(duckdb.JSONContains("payload", map[string]interface{}{"tags": []string{"new"}}))
+func JSONContains(column string, value interface{}) clause.Expr {
+	return clause.Expr{SQL: "json_contains(?, ?::JSON)", Vars: []interface{}{clause.Column{Name: column}, JSONType{Data: value}}}
+}
+
+// JSONEach returns a table function expression for json_each(column), one row
+// per element of a JSON array (or per key of an object) with key, value, type,
+// fullkey and path columns. Use it in a lateral join, qualifying the column
+// with its table:
+//
+//	db.Table("orders").Joins("CROSS JOIN LATERAL ? AS item", duckdb.JSONEach("orders.lines"))
+func JSONEach(column string) clause.Expr {
+	return clause.Expr{SQL: "json_each(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// JSONTree is like JSONEach but walks nested arrays and objects recursively,
+// producing a row for every node; parent holds the id of the enclosing node.
+func JSONTree(column string) clause.Expr {
+	return clause.Expr{SQL: "json_tree(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ===== SPATIAL FUNCTIONS =====
+
+// The ST_ helpers need the spatial extension (ExtensionHelper.EnableSpatial).
+// Their geometry operands are a column name, a GEOMETRYType value (bound as
+// ST_GeomFromText of its WKT) or any other clause.Expression.
+
+// STGeomFromText returns an expression for ST_GeomFromText(wkt) with the WKT bound
+func STGeomFromText(wkt string) clause.Expr {
+	return clause.Expr{SQL: "ST_GeomFromText(?)", Vars: []interface{}{wkt}}
+}
+
+// STDistance returns an expression for ST_Distance(a, b), the planar distance
+// between two geometries in the units of their coordinates:
+//
+//	db.Where("? <= ?", duckdb.STDistance("location", duckdb.NewGeometry("POINT(0 0)", 0)), 5.0)
+func STDistance(a, b interface{}) clause.Expr {
+	return clause.Expr{SQL: "ST_Distance(?, ?)", Vars: []interface{}{spatialOperand(a), spatialOperand(b)}}
+}
+
+// STContains returns a condition that is true when the geometry column
+// contains the WKT geometry
+func STContains(column, wkt string) clause.Expr {
+	return clause.Expr{SQL: "ST_Contains(?, ?)", Vars: []interface{}{clause.Column{Name: column}, STGeomFromText(wkt)}}
+}
+
+// STWithin returns a condition that is true when the geometry column lies
+// within the WKT geometry
+func STWithin(column, wkt string) clause.Expr {
+	return clause.Expr{SQL: "ST_Within(?, ?)", Vars: []interface{}{clause.Column{Name: column}, STGeomFromText(wkt)}}
+}
+
+// spatialOperand turns a column name or GEOMETRYType into an ST_ argument
+func spatialOperand(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return clause.Column{Name: v}
+	case GEOMETRYType:
+		return STGeomFromText(v.WKT)
+	case *GEOMETRYType:
+		return STGeomFromText(v.WKT)
+	}
+	return v
+}
+
+// ===== TIME SERIES =====
+
+// TimeSeries returns a table expression over generate_series(start, end, interval),
+// producing one TIMESTAMP row per bucket with both bounds inclusive. The result
+// column is named generate_series unless aliased. Use it as the left side of a
+// LEFT JOIN to fill gaps in time-bucketed reports:
+//
+//	db.Table("? AS buckets(bucket)", duckdb.TimeSeries(start, end, duckdb.NewInterval(0, 0, 0, 1, 0, 0, 0))).
+//		Joins("LEFT JOIN events ON date_trunc('hour', events.created_at) = buckets.bucket")
+func TimeSeries(start, end time.Time, interval IntervalType) clause.Expr {
+	return clause.Expr{
+		SQL:  "generate_series(?::TIMESTAMP, ?::TIMESTAMP, ?::INTERVAL)",
+		Vars: []interface{}{start, end, interval.literal()},
+	}
+}
+
+// ===== SET OPERATIONS =====
+
+// UnionByName combines built queries with UNION BY NAME, which aligns columns by
+// name rather than position and fills columns missing from a query with NULL.
+// Duplicate rows are removed. Bind variables of every query are carried over:
+//
+//	db.Raw("?", duckdb.UnionByName(db.Table("a").Select("id, name"), db.Table("b").Select("name, id"))).Scan(&rows)
+func UnionByName(queries ...*gorm.DB) clause.Expr {
+	return setOperation("UNION BY NAME", queries)
+}
+
+// UnionAllByName is UnionByName keeping duplicate rows (UNION ALL BY NAME).
+func UnionAllByName(queries ...*gorm.DB) clause.Expr {
+	return setOperation("UNION ALL BY NAME", queries)
+}
+
+// setOperation joins each query, parenthesized, with the given set operator
+func setOperation(operator string, queries []*gorm.DB) clause.Expr {
+	parts := make([]string, len(queries))
+	vars := make([]interface{}, len(queries))
+	for i, query := range queries {
+		parts[i] = "(?)"
+		vars[i] = query
+	}
+	return clause.Expr{SQL: strings.Join(parts, " "+operator+" "), Vars: vars}
+}
+
+// ===== LIST FUNCTIONS =====
+
+// ListPosition returns an expression for list_position(column, value): the
+// 1-based index of value in the list column, or NULL when absent. The value is
+// bound. Wrap it in clause.OrderBy to sort by priority lists:
+//
+//	db.Order(clause.OrderBy{Expression: duckdb.ListPosition("tags", "urgent")})
+func ListPosition(column string, value interface{}) clause.Expr {
+	return clause.Expr{SQL: "list_position(?, ?)", Vars: []interface{}{clause.Column{Name: column}, value}}
+}
+
+// ListZip returns an expression zipping parallel list columns into a list of
+// structs, one field per column named after it; shorter lists are padded with
+// NULL. Plain list_zip yields unnamed struct fields, which go-duckdb cannot
+// scan, so the pairs are repacked with struct_pack. Unnest the result to get
+// one row per element:
+//
+//	db.Table("t").Select("unnest(?, recursive := true)", duckdb.ListZip("keys", "vals"))
+func ListZip(columns ...string) clause.Expr {
+	placeholders := make([]string, len(columns))
+	fields := make([]string, len(columns))
+	vars := make([]interface{}, 0, 2*len(columns))
+	for i, column := range columns {
+		placeholders[i] = "?"
+		fields[i] = fmt.Sprintf("? := z[%d]", i+1)
+		vars = append(vars, clause.Column{Name: column})
+	}
+	for _, column := range columns {
+		vars = append(vars, clause.Column{Name: column[strings.LastIndex(column, ".")+1:]})
+	}
+
+	return clause.Expr{
+		SQL:  fmt.Sprintf("list_transform(list_zip(%s), z -> struct_pack(%s))", strings.Join(placeholders, ", "), strings.Join(fields, ", ")),
+		Vars: vars,
+	}
+}
+
+// Unnest returns an expression for unnest(column), which explodes a list
+// column into one row per element when selected; the other selected columns
+// repeat on each row, and rows with empty or NULL lists produce none:
+//
+//	db.Model(&Post{}).Select("id, ? AS tag", duckdb.Unnest("tags")).Scan(&postTags)
+func Unnest(column string) clause.Expr {
+	return clause.Expr{SQL: "unnest(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// UnnestSeries returns an expression for unnest(generate_series(start, stop,
+// step)), one row per value from start to stop inclusive when selected, for
+// repeating each row a fixed number of times. The bounds are bound as
+// parameters. To use the series as a table, select from generate_series
+// directly, e.g. db.Table("generate_series(?, ?) AS s(n)", 1, 10).
+func UnnestSeries(start, stop, step int64) clause.Expr {
+	return clause.Expr{SQL: "unnest(generate_series(?, ?, ?))", Vars: []interface{}{start, stop, step}}
+}
+
+// ArraySliceStep returns an expression for array_slice(column, begin, end,
+// step): every step-th element from begin to end, both 1-based and inclusive.
+// Negative bounds count from the end of the list, so begin 1, end -1, step 10
+// keeps every tenth element. The bounds are bound as parameters.
+func ArraySliceStep(column string, begin, end, step int) clause.Expr {
+	return clause.Expr{SQL: "array_slice(?, ?, ?, ?)", Vars: []interface{}{clause.Column{Name: column}, begin, end, step}}
+}
+
+// ===== DATE FUNCTIONS =====
+
+// Strftime returns an expression for strftime(column, format), formatting a
+// DATE/TIMESTAMP column as text. The format is bound, e.g. "%Y-%m" for months.
+func Strftime(column, format string) clause.Expr {
+	return clause.Expr{SQL: "strftime(?, ?)", Vars: []interface{}{clause.Column{Name: column}, format}}
+}
+
+// Strptime returns an expression for strptime(column, format), parsing a text
+// column into a TIMESTAMP. The format is bound, e.g. "%d/%m/%Y".
+func Strptime(column, format string) clause.Expr {
+	return clause.Expr{SQL: "strptime(?, ?)", Vars: []interface{}{clause.Column{Name: column}, format}}
+}
+
+// ===== APPROXIMATE AGGREGATES =====
+
+// ApproxCountDistinct returns an expression for approx_count_distinct(column),
+// a HyperLogLog estimate of the number of distinct values.
+func ApproxCountDistinct(column string) clause.Expr {
+	return clause.Expr{SQL: "approx_count_distinct(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ReservoirQuantile returns an expression for reservoir_quantile(column, q), an
+// approximate quantile computed from a reservoir sample. q (0..1) is bound.
+func ReservoirQuantile(column string, q float64) clause.Expr {
+	return clause.Expr{SQL: "reservoir_quantile(?, ?)", Vars: []interface{}{clause.Column{Name: column}, q}}
+}
+
+// Histogram returns an expression for histogram(column), a MAP from each
+// distinct value to its number of occurrences. It scans into MapType, with
+// keys rendered as strings and counts as uint64.
+func Histogram(column string) clause.Expr {
+	return clause.Expr{SQL: "histogram(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// BitstringAgg returns an expression for bitstring_agg(column, min, max), a
+// bitmap with one bit per integer in [min, max] that is set when the value
+// occurs. Sketches from different groups merge with BitstringMerge and give an
+// exact distinct count through BitstringCount. go-duckdb cannot scan BIT
+// values, so the result is cast to VARCHAR and scans into BitStringType.
+func BitstringAgg(column string, min, max int64) clause.Expr {
+	return clause.Expr{SQL: "CAST(bitstring_agg(?, ?, ?) AS VARCHAR)", Vars: []interface{}{clause.Column{Name: column}, min, max}}
+}
+
+// BitstringMerge returns an aggregate OR-ing the sketches stored in column, as
+// VARCHAR for scanning into BitStringType.
+func BitstringMerge(column string) clause.Expr {
+	return clause.Expr{SQL: "CAST(bit_or(CAST(? AS BIT)) AS VARCHAR)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// BitstringCount returns an expression counting the set bits of the sketch in
+// column, i.e. the number of distinct values it records.
+func BitstringCount(column string) clause.Expr {
+	return clause.Expr{SQL: "bit_count(CAST(? AS BIT))", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ===== WINDOW CLAUSE =====
+
+// NamedWindow is one "name AS (spec)" entry of a WINDOW clause
+type NamedWindow struct {
+	Name string
+	Spec string
+}
+
+// WindowClause renders DuckDB's WINDOW clause. Add it with db.Clauses; windows
+// from several Window calls on the same query are merged into one clause.
+type WindowClause struct {
+	Windows []NamedWindow
+}
+
+// Window returns a WINDOW clause defining name as the given OVER specification,
+// so several window functions can share it (OVER name, or
+// AnalyticalFunctionType.WindowName):
+//
+//	db.Clauses(duckdb.Window("w", "PARTITION BY dept ORDER BY salary DESC")).
+//		Select("name, rank() OVER w, sum(salary) OVER w")
+func Window(name, spec string) WindowClause {
+	return WindowClause{Windows: []NamedWindow{{Name: name, Spec: spec}}}
+}
+
+// Name implements clause.Interface
+func (WindowClause) Name() string {
+	return "WINDOW"
+}
+
+// Build implements clause.Expression
+func (w WindowClause) Build(builder clause.Builder) {
+	for i, window := range w.Windows {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteQuoted(window.Name)
+		builder.WriteString(" AS (")
+		builder.WriteString(window.Spec)
+		builder.WriteByte(')')
+	}
+}
+
+// MergeClause implements clause.Interface, appending to windows already on the query
+func (w WindowClause) MergeClause(c *clause.Clause) {
+	if existing, ok := c.Expression.(WindowClause); ok {
+		windows := make([]NamedWindow, 0, len(existing.Windows)+len(w.Windows))
+		windows = append(windows, existing.Windows...)
+		w.Windows = append(windows, w.Windows...)
+	}
+	c.Expression = w
+}
+
+// ListHasAny returns a predicate for list_has_any(column, values): true when the
+// list column shares at least one element with values. values is a Go slice
+// (or StringArray/IntArray/FloatArray) bound as a single list parameter.
+func ListHasAny(column string, values interface{}) clause.Expr {
+	return clause.Expr{SQL: "list_has_any(?, ?)", Vars: []interface{}{clause.Column{Name: column}, listParam(values)}}
+}
+
+// ListHasAll returns a predicate for list_has_all(column, values): true when the
+// list column contains every element of values.
+func ListHasAll(column string, values interface{}) clause.Expr {
+	return clause.Expr{SQL: "list_has_all(?, ?)", Vars: []interface{}{clause.Column{Name: column}, listParam(values)}}
+}
+
+// ArrayContains returns a predicate for list_contains(column, value): true
+// when the list column holds value, which is bound as a parameter:
+//
+//	db.Where(duckdb.ArrayContains("tags", "urgent")).Find(&tickets)
+func ArrayContains(column string, value interface{}) clause.Expr {
+	return clause.Expr{SQL: "list_contains(?, ?)", Vars: []interface{}{clause.Column{Name: column}, value}}
+}
+
+// ArrayLength returns an expression for the number of elements of the list
+// column, for comparisons such as db.Where("? > ?", duckdb.ArrayLength("tags"), 2).
+// It is NULL for NULL lists.
+func ArrayLength(column string) clause.Expr {
+	return clause.Expr{SQL: "len(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ArrayOverlaps is ListHasAny: true when the list column shares at least one
+// element with values
+func ArrayOverlaps(column string, values interface{}) clause.Expr {
+	return ListHasAny(column, values)
+}
+
+// ArrayHasAll is ListHasAll: true when the list column contains every element
+// of values
+func ArrayHasAll(column string, values interface{}) clause.Expr {
+	return ListHasAll(column, values)
+}
+
+// ===== QUALIFY CLAUSE =====
+
+// QualifyClause renders DuckDB's QUALIFY clause, which filters on window
+// function results the way HAVING filters on aggregates. Add it with db.Clauses;
+// conditions from several Qualify calls are ANDed.
+type QualifyClause struct {
+	Exprs []clause.Expression
+}
+
+// Qualify returns a QUALIFY clause for the given condition, with vars bound:
+//
+//	db.Clauses(duckdb.Qualify("row_number() OVER (PARTITION BY user_id ORDER BY ts DESC) = ?", 1))
+func Qualify(condition string, vars ...interface{}) QualifyClause {
+	return QualifyClause{Exprs: []clause.Expression{clause.Expr{SQL: condition, Vars: vars}}}
+}
+
+// Name implements clause.Interface
+func (QualifyClause) Name() string {
+	return "QUALIFY"
+}
+
+// Build implements clause.Expression
+func (q QualifyClause) Build(builder clause.Builder) {
+	for i, expr := range q.Exprs {
+		if i > 0 {
+			builder.WriteString(" AND ")
+		}
+		if len(q.Exprs) > 1 {
+			builder.WriteByte('(')
+			expr.Build(builder)
+			builder.WriteByte(')')
+		} else {
+			expr.Build(builder)
+		}
+	}
+}
+
+// MergeClause implements clause.Interface, ANDing with conditions already on the query
+func (q QualifyClause) MergeClause(c *clause.Clause) {
+	if existing, ok := c.Expression.(QualifyClause); ok {
+		exprs := make([]clause.Expression, 0, len(existing.Exprs)+len(q.Exprs))
+		exprs = append(exprs, existing.Exprs...)
+		q.Exprs = append(exprs, q.Exprs...)
+	}
+	c.Expression = q
+}
+
+// TopNPerGroup runs db's query keeping the first n rows of each partitionBy
+// group under orderBy (e.g. "price DESC, id"), scanning them into dest. It
+// numbers rows with row_number() and filters them with QUALIFY, so ties are
+// broken arbitrarily unless orderBy is unique. Column names are quoted, n is
+// bound, and a term may only carry ASC/DESC and NULLS FIRST/LAST modifiers.
+func TopNPerGroup(db *gorm.DB, partitionBy []string, orderBy string, n int, dest interface{}) error {
+	var terms []orderTerm
+	if orderBy = strings.TrimSpace(orderBy); orderBy != "" {
+		var err error
+		if terms, err = parseOrderTerms(orderBy); err != nil {
+			return err
+		}
+	}
+	return topNPerGroup(db, partitionBy, terms, n, dest)
+}
+
+// topNPerGroup filters db's query with TopNPerGroup's QUALIFY for parsed order terms
+func topNPerGroup(db *gorm.DB, partitionBy []string, terms []orderTerm, n int, dest interface{}) error {
+	var (
+		sql  strings.Builder
+		vars []interface{}
+	)
+
+	sql.WriteString("row_number() OVER (")
+	if len(partitionBy) > 0 {
+		sql.WriteString("PARTITION BY ")
+		for i, column := range partitionBy {
+			if i > 0 {
+				sql.WriteString(", ")
+			}
+			sql.WriteString("?")
+			vars = append(vars, clause.Column{Name: column})
+		}
+	}
+
+	if len(terms) > 0 {
+		if len(partitionBy) > 0 {
+			sql.WriteString(" ")
+		}
+		sql.WriteString("ORDER BY ")
+		for i, term := range terms {
+			if i > 0 {
+				sql.WriteString(", ")
+			}
+			sql.WriteString("?" + term.modifiers())
+			vars = append(vars, clause.Column{Name: term.Column})
+		}
+	}
+	sql.WriteString(") <= ?")
+	vars = append(vars, n)
+
+	return db.Clauses(QualifyClause{Exprs: []clause.Expression{clause.Expr{SQL: sql.String(), Vars: vars}}}).
+		Find(dest).Error
+}
+
+// orderTerm is one column of an ORDER BY list with its optional modifiers
+type orderTerm struct {
+	Column    string
+	Direction string // ASC, DESC or empty
+	Nulls     string // FIRST, LAST or empty
+}
+
+// modifiers renders the direction and NULLS placement following the column
+func (t orderTerm) modifiers() string {
+	var s string
+	if t.Direction != "" {
+		s += " " + t.Direction
+	}
+	if t.Nulls != "" {
+		s += " NULLS " + t.Nulls
+	}
+	return s
+}
+
+// parseOrderTerms splits orderBy (e.g. "price DESC, id NULLS LAST") into its
+// terms. Only ASC or DESC followed by NULLS FIRST or NULLS LAST may follow a
+// column; anything else, including an empty term, is rejected rather than
+// written into the query.
+func parseOrderTerms(orderBy string) ([]orderTerm, error) {
+	var terms []orderTerm
+	for _, part := range strings.Split(orderBy, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("empty order term in %q", orderBy)
+		}
+
+		term := orderTerm{Column: fields[0]}
+		modifiers := fields[1:]
+		if len(modifiers) > 0 {
+			if direction := strings.ToUpper(modifiers[0]); direction == "ASC" || direction == "DESC" {
+				term.Direction = direction
+				modifiers = modifiers[1:]
+			}
+		}
+		if len(modifiers) == 2 && strings.EqualFold(modifiers[0], "NULLS") {
+			if nulls := strings.ToUpper(modifiers[1]); nulls == "FIRST" || nulls == "LAST" {
+				term.Nulls = nulls
+				modifiers = nil
+			}
+		}
+		if len(modifiers) > 0 {
+			return nil, fmt.Errorf("invalid order term %q: only ASC, DESC, NULLS FIRST and NULLS LAST may follow the column", strings.TrimSpace(part))
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+// LatestPerKey runs db's query keeping one row per keyColumns combination,
+// the one that sorts first under orderBy, and scans them into dest. Terms of
+// orderBy without a direction sort descending, so "updated_at" keeps the most
+// recent version of each key; "version DESC, id DESC" is equivalent spelled out.
+// Terms are checked as for TopNPerGroup, and orderBy must not be empty.
+func LatestPerKey(db *gorm.DB, keyColumns []string, orderBy string, dest interface{}) error {
+	terms, err := parseOrderTerms(orderBy)
+	if err != nil {
+		return err
+	}
+	for i := range terms {
+		if terms[i].Direction == "" {
+			terms[i].Direction = "DESC"
+		}
+	}
+	return topNPerGroup(db, keyColumns, terms, 1, dest)
+}
+
+// ===== PIVOT =====
+
+// Pivot describes a DuckDB PIVOT statement turning the distinct values of On
+// into columns, aggregated with Using per GroupBy combination:
+//
+//	PIVOT sales ON quarter IN ('Q1', 'Q2') USING sum(amount) GROUP BY region
+type Pivot struct {
+	Table string
+	On    string
+	// Values fixes the output columns, in order; empty pivots on every
+	// distinct value, so the columns are only known once the query runs.
+	Values []interface{}
+	// Using is the aggregate SQL, e.g. "sum(amount)". With an alias the output
+	// columns are named <value>_<alias>.
+	Using   string
+	GroupBy []string
+}
+
+// Build renders the PIVOT statement
+func (p Pivot) Build(db *gorm.DB) (string, error) {
+	if p.Table == "" || p.On == "" || p.Using == "" {
+		return "", fmt.Errorf("pivot needs a table, an ON column and a USING aggregate")
+	}
+
+	var sql strings.Builder
+	fmt.Fprintf(&sql, "PIVOT %s ON %s", db.Statement.Quote(p.Table), db.Statement.Quote(p.On))
+	if len(p.Values) > 0 {
+		values := make([]string, len(p.Values))
+		for i, value := range p.Values {
+			literal, err := sqlLiteral(value)
+			if err != nil {
+				return "", fmt.Errorf("invalid pivot value: %w", err)
+			}
+			values[i] = literal
+		}
+		sql.WriteString(" IN (" + strings.Join(values, ", ") + ")")
+	}
+	sql.WriteString(" USING " + p.Using)
+	if len(p.GroupBy) > 0 {
+		columns := make([]string, len(p.GroupBy))
+		for i, column := range p.GroupBy {
+			columns[i] = db.Statement.Quote(column)
+		}
+		sql.WriteString(" GROUP BY " + strings.Join(columns, ", "))
+	}
+	return sql.String(), nil
+}
+
+// PivotInto runs p and scans the rows into a predeclared wide struct, matching
+// output columns to fields by column or field name (use gorm:"column:..." for
+// values that are not valid Go names). Pivots whose columns are not known in
+// advance can use PivotMaps instead.
+func PivotInto[T any](ctx context.Context, db *gorm.DB, p Pivot) ([]T, error) {
+	sql, err := p.Build(db)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := gorm.G[T](db).Raw(sql).Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pivot %s on %s: %w", p.Table, p.On, err)
+	}
+	return rows, nil
+}
+
+// PivotMaps runs p and returns each row as a map keyed by output column, for
+// pivots over values only known at run time.
+func PivotMaps(ctx context.Context, db *gorm.DB, p Pivot) ([]map[string]interface{}, error) {
+	return PivotInto[map[string]interface{}](ctx, db, p)
+}
+
+// ===== QUERY PLANS =====
+
+// PlanNode is one operator of a physical plan returned by ExplainJSON
+type PlanNode struct {
+	Name     string
+	Children []*PlanNode
+	// ExtraInfo holds operator details such as "Table", "Filters" and
+	// "Projections" as DuckDB reports them.
+	ExtraInfo map[string]interface{}
+	// EstimatedCardinality is the planner's row estimate, -1 when not reported.
+	EstimatedCardinality int64
+}
+
+// ExplainJSON returns the physical plan of db's query, built with Raw or with
+// Model/Table and conditions as for Find, from EXPLAIN (FORMAT json).
+func ExplainJSON(db *gorm.DB) (*PlanNode, error) {
+	stmt := db.Statement
+	if stmt.SQL.Len() == 0 {
+		var rows []map[string]interface{}
+		stmt = db.Session(&gorm.Session{DryRun: true}).Find(&rows).Statement
+		if stmt.Error != nil {
+			return nil, fmt.Errorf("failed to build query to explain: %w", stmt.Error)
+		}
+	}
+
+	var plans []struct {
+		ExplainKey   string
+		ExplainValue string
+	}
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Raw("EXPLAIN (FORMAT json) "+stmt.SQL.String(), stmt.Vars...).Scan(&plans).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain query: %w", err)
+	}
+	if len(plans) == 0 {
+		return nil, fmt.Errorf("EXPLAIN returned no plan")
+	}
+
+	var nodes []jsonPlanNode
+	if err := json.Unmarshal([]byte(plans[len(plans)-1].ExplainValue), &nodes); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("EXPLAIN returned an empty plan")
+	}
+	return nodes[0].planNode(), nil
+}
+
+// jsonPlanNode mirrors one node of DuckDB's JSON plan output
+type jsonPlanNode struct {
+	Name      string                 `json:"name"`
+	Children  []jsonPlanNode         `json:"children"`
+	ExtraInfo map[string]interface{} `json:"extra_info"`
+}
+
+func (n jsonPlanNode) planNode() *PlanNode {
+	node := &PlanNode{
+		Name:                 strings.TrimSpace(n.Name),
+		ExtraInfo:            n.ExtraInfo,
+		EstimatedCardinality: -1,
+	}
+	if estimate, ok := n.ExtraInfo["Estimated Cardinality"].(string); ok {
+		if rows, err := strconv.ParseInt(strings.TrimPrefix(estimate, "~"), 10, 64); err == nil {
+			node.EstimatedCardinality = rows
+		}
+	}
+	for _, child := range n.Children {
+		node.Children = append(node.Children, child.planNode())
+	}
+	return node
+}
+
+// ===== UPSERTS =====
+
+// BulkUpsert inserts values (a pointer to a slice of models) with ON CONFLICT
+// on conflictColumns, updating updateColumns of rows that already exist, and
+// reports how many rows were inserted and how many were updated. With no
+// updateColumns existing rows are left alone and count towards neither.
+//
+// DuckDB returns a single affected count for the statement, so the split is
+// derived from the table's row count before and after, inside one transaction.
+// Generated IDs of inserted rows are not read back into values.
+func BulkUpsert(db *gorm.DB, values interface{}, conflictColumns, updateColumns []string) (inserted, updated int64, err error) {
+	if len(conflictColumns) == 0 {
+		return 0, 0, fmt.Errorf("bulk upsert needs at least one conflict column")
+	}
+	table, err := resolveModelTable(db, values)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	onConflict := clause.OnConflict{DoNothing: len(updateColumns) == 0}
+	for _, column := range conflictColumns {
+		onConflict.Columns = append(onConflict.Columns, clause.Column{Name: column})
+	}
+	if len(updateColumns) > 0 {
+		onConflict.DoUpdates = clause.AssignmentColumns(updateColumns)
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var before, after int64
+		if err := tx.Table(table).Count(&before).Error; err != nil {
+			return err
+		}
+		result := tx.Clauses(onConflict).Create(values)
+		if result.Error != nil {
+			return result.Error
+		}
+		if err := tx.Table(table).Count(&after).Error; err != nil {
+			return err
+		}
+		inserted = after - before
+		updated = result.RowsAffected - inserted
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to upsert into %s: %w", table, err)
+	}
+	return inserted, updated, nil
+}
+
+// ===== VECTOR SEARCH =====
+
+// VectorSearch finds the rows of a table whose vector column is most similar
+// to a query vector. The statement is prepared once and reused by every TopK
+// call, with only the query vector and k bound.
+type VectorSearch struct {
+	db  *gorm.DB
+	sql string
+}
+
+// NewVectorSearch prepares similarity searches over vectorColumn of table, a
+// FLOAT or DOUBLE list or array column (e.g. VectorType, FloatArray). Results
+// are ordered by list_cosine_similarity, most similar first.
+func NewVectorSearch(db *gorm.DB, table, vectorColumn string) (*VectorSearch, error) {
+	quotedTable := db.Statement.Quote(table)
+
+	var columns []describedColumn
+	if err := db.Raw("DESCRIBE " + quotedTable).Scan(&columns).Error; err != nil {
+		return nil, fmt.Errorf("failed to describe table %s: %w", table, err)
+	}
+
+	var elementType string
+	for _, column := range columns {
+		if column.ColumnName != vectorColumn {
+			continue
+		}
+		columnType := strings.ToUpper(column.ColumnType)
+		element := listSuffixPattern.ReplaceAllString(columnType, "")
+		if element == columnType || (element != "FLOAT" && element != "DOUBLE") {
+			return nil, fmt.Errorf("column %s of %s has type %s, expected a FLOAT or DOUBLE list", vectorColumn, table, column.ColumnType)
+		}
+		elementType = element
+		break
+	}
+	if elementType == "" {
+		return nil, fmt.Errorf("table %s has no column %s", table, vectorColumn)
+	}
+
+	return &VectorSearch{
+		db: db.Session(&gorm.Session{PrepareStmt: true, NewDB: true}),
+		sql: fmt.Sprintf("SELECT * FROM %s ORDER BY list_cosine_similarity(%s, CAST(? AS %s[])) DESC NULLS LAST LIMIT ?",
+			quotedTable, db.Statement.Quote(vectorColumn), elementType),
+	}, nil
+}
+
+// TopK scans the k rows most similar to query into dest
+func (s *VectorSearch) TopK(query []float32, k int, dest interface{}) error {
+	if len(query) == 0 {
+		return fmt.Errorf("query vector must have at least one element")
+	}
+	if k <= 0 {
+		return fmt.Errorf("k must be positive, got %d", k)
+	}
+
+	vector := make(FloatArray, len(query))
+	for i, f := range query {
+		vector[i] = float64(f)
+	}
+	return s.db.Raw(s.sql, vector, k).Scan(dest).Error
+}
@@ -0,0 +1,1088 @@
+package duckdb_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+func setupQueryHelperTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestMapHelpers(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	require.NoError(t, db.Exec(`CREATE TABLE settings (id INTEGER, attrs MAP(VARCHAR, VARCHAR))`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO settings VALUES
+		(1, MAP {'color': 'red', 'size': 'L'}),
+		(2, MAP {'color': 'blue'})`).Error)
+
+	type result struct {
+		ID      int
+		Keys    duckdb.StringArray
+		Vals    duckdb.StringArray
+		Entries duckdb.ListType
+	}
+
+	var rows []result
+	err := db.Table("settings").
+		Select("id, ? AS keys, ? AS vals, ? AS entries",
+			duckdb.MapKeys("attrs"), duckdb.MapValues("attrs"), duckdb.MapEntries("attrs")).
+		Order("id").
+		Scan(&rows).Error
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, duckdb.StringArray{"color", "size"}, rows[0].Keys)
+	assert.Equal(t, duckdb.StringArray{"red", "L"}, rows[0].Vals)
+	assert.Equal(t, duckdb.StringArray{"color"}, rows[1].Keys)
+	assert.Equal(t, duckdb.StringArray{"blue"}, rows[1].Vals)
+
+	require.Len(t, rows[1].Entries, 1)
+	entry, ok := rows[1].Entries[0].(map[string]interface{})
+	require.True(t, ok, "entry should scan as a struct, got %T", rows[1].Entries[0])
+	assert.Equal(t, "color", entry["key"])
+	assert.Equal(t, "blue", entry["value"])
+}
+
+func TestMapHelpers_QuotesColumn(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("settings").Select("?", duckdb.MapKeys("attrs")).Find(&[]map[string]interface{}{})
+	})
+	assert.Contains(t, sql, `map_keys("attrs")`)
+}
+
+func TestStructStar(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	require.NoError(t, db.Exec(`CREATE TABLE devices (id INTEGER, spec STRUCT(vendor VARCHAR, cores INTEGER, turbo BOOLEAN))`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO devices VALUES
+		(1, {vendor: 'acme', cores: 8, turbo: true}),
+		(2, {vendor: 'initech', cores: 4, turbo: false})`).Error)
+
+	type flatDevice struct {
+		ID     int
+		Vendor string
+		Cores  int
+		Turbo  bool
+	}
+	var devices []flatDevice
+	err := db.Table("devices").Select("id, ?", duckdb.StructStar("spec")).Order("id").Find(&devices).Error
+	require.NoError(t, err)
+
+	assert.Equal(t, []flatDevice{
+		{ID: 1, Vendor: "acme", Cores: 8, Turbo: true},
+		{ID: 2, Vendor: "initech", Cores: 4, Turbo: false},
+	}, devices)
+}
+
+func TestJSONMergePatch(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	type Document struct {
+		ID      uint            `gorm:"primaryKey"`
+		Payload duckdb.JSONType `gorm:"type:JSON"`
+	}
+	require.NoError(t, db.AutoMigrate(&Document{}))
+
+	doc := Document{Payload: duckdb.NewJSON(map[string]interface{}{
+		"status": "draft",
+		"meta":   map[string]interface{}{"author": "ann", "rev": 1},
+		"tmp":    true,
+	})}
+	require.NoError(t, db.Create(&doc).Error)
+
+	patch := map[string]interface{}{
+		"status": "published",
+		"meta":   map[string]interface{}{"rev": 2},
+		"tmp":    nil,
+	}
+	require.NoError(t, db.Model(&doc).Updates(duckdb.JSONMergePatch("payload", patch)).Error)
+
+	var merged string
+	require.NoError(t, db.Raw("SELECT CAST(payload AS VARCHAR) FROM documents WHERE id = ?", doc.ID).Scan(&merged).Error)
+	assert.JSONEq(t, `{"status":"published","meta":{"author":"ann","rev":2}}`, merged)
+}
+
+func TestJSONExtract(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	type Event struct {
+		ID      uint `gorm:"primaryKey"`
+		Payload duckdb.JSONType
+	}
+	require.NoError(t, db.AutoMigrate(&Event{}))
+	for _, payload := range []map[string]interface{}{
+		{"user": map[string]interface{}{"name": "ann", "it's": "quoted"}, "tags": []string{"new", "vip"}},
+		{"user": map[string]interface{}{"name": "bob"}, "tags": []string{"vip"}},
+		{"tags": []string{}},
+	} {
+		require.NoError(t, db.Create(&Event{Payload: duckdb.NewJSON(payload)}).Error)
+	}
+
+	var ids []uint
+	require.NoError(t, db.Model(&Event{}).
+		Where(clause.Eq{Column: duckdb.JSONExtract("payload", "$.user.name"), Value: "bob"}).
+		Pluck("id", &ids).Error)
+	assert.Equal(t, []uint{2}, ids)
+
+	var names []struct{ Name *string }
+	require.NoError(t, db.Model(&Event{}).Select("? AS name", duckdb.JSONExtract("payload", "/user/name")).Order("id").Scan(&names).Error)
+	require.Len(t, names, 3)
+	assert.Equal(t, "ann", *names[0].Name)
+	assert.Equal(t, "bob", *names[1].Name)
+	assert.Nil(t, names[2].Name, "missing paths are NULL")
+
+	// The path is bound, so quotes in it cannot break out of the query
+	var quoted string
+	require.NoError(t, db.Model(&Event{}).Select("?", duckdb.JSONExtract("payload", `$.user."it's"`)).Where("id = 1").Scan(&quoted).Error)
+	assert.Equal(t, "quoted", quoted)
+
+	ids = nil
+	require.NoError(t, db.Model(&Event{}).
+		Where(duckdb.JSONContains("payload", map[string]interface{}{"tags": []string{"vip"}})).
+		Order("id").Pluck("id", &ids).Error)
+	assert.Equal(t, []uint{1, 2}, ids)
+
+	ids = nil
+	require.NoError(t, db.Model(&Event{}).
+		Where(duckdb.JSONContains("payload", map[string]interface{}{"user": map[string]interface{}{"name": "ann"}})).
+		Pluck("id", &ids).Error)
+	assert.Equal(t, []uint{1}, ids)
+}
+
+func TestJSONEach(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	type Basket struct {
+		ID    uint `gorm:"primaryKey"`
+		Lines duckdb.JSONType
+	}
+	require.NoError(t, db.AutoMigrate(&Basket{}))
+	require.NoError(t, db.Create(&Basket{Lines: duckdb.NewJSON([]map[string]interface{}{
+		{"sku": "apple", "qty": 3},
+		{"sku": "pear", "qty": 1},
+	})}).Error)
+	require.NoError(t, db.Create(&Basket{Lines: duckdb.NewJSON([]map[string]interface{}{
+		{"sku": "plum", "qty": 6},
+	})}).Error)
+
+	type BasketLine struct {
+		BasketID uint
+		Position string
+		Sku      string
+		Qty      int
+	}
+	var lines []BasketLine
+	err := db.Table("baskets").
+		Joins("CROSS JOIN LATERAL ? AS line", duckdb.JSONEach("baskets.lines")).
+		Select("baskets.id AS basket_id, line.key AS position, line.value->>'sku' AS sku, CAST(line.value->>'qty' AS INTEGER) AS qty").
+		Order("baskets.id, line.key").
+		Scan(&lines).Error
+	require.NoError(t, err)
+	assert.Equal(t, []BasketLine{
+		{BasketID: 1, Position: "0", Sku: "apple", Qty: 3},
+		{BasketID: 1, Position: "1", Sku: "pear", Qty: 1},
+		{BasketID: 2, Position: "0", Sku: "plum", Qty: 6},
+	}, lines)
+
+	// json_tree also visits the scalar leaves inside each object
+	var leaves int64
+	err = db.Table("baskets").
+		Joins("CROSS JOIN LATERAL ? AS node", duckdb.JSONTree("baskets.lines")).
+		Where("node.atom IS NOT NULL").
+		Count(&leaves).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), leaves)
+}
+
+func TestTimeSeries(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	hourly := duckdb.NewInterval(0, 0, 0, 1, 0, 0, 0)
+
+	var buckets []time.Time
+	err := db.Table("? AS buckets(bucket)", duckdb.TimeSeries(start, end, hourly)).
+		Order("bucket").
+		Pluck("bucket", &buckets).Error
+	require.NoError(t, err)
+	require.Len(t, buckets, 25, "both bounds are inclusive")
+	assert.True(t, buckets[0].Equal(start))
+	assert.True(t, buckets[24].Equal(end))
+
+	// LEFT JOIN against the series fills missing buckets with zeros
+	require.NoError(t, db.Exec(`CREATE TABLE events (created_at TIMESTAMP)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO events VALUES (?), (?), (?)`,
+		start.Add(30*time.Minute), start.Add(45*time.Minute), start.Add(5*time.Hour)).Error)
+
+	type bucketCount struct {
+		Bucket time.Time
+		Total  int
+	}
+	var counts []bucketCount
+	err = db.Table("? AS buckets(bucket)", duckdb.TimeSeries(start, end.Add(-time.Hour), hourly)).
+		Select("buckets.bucket, COUNT(events.created_at) AS total").
+		Joins("LEFT JOIN events ON date_trunc('hour', events.created_at) = buckets.bucket").
+		Group("buckets.bucket").
+		Order("buckets.bucket").
+		Scan(&counts).Error
+	require.NoError(t, err)
+	require.Len(t, counts, 24)
+	assert.Equal(t, 2, counts[0].Total)
+	assert.Equal(t, 0, counts[1].Total)
+	assert.Equal(t, 1, counts[5].Total)
+}
+
+func TestUnionByName(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	require.NoError(t, db.Exec(`CREATE TABLE staff (id INTEGER, name VARCHAR, dept VARCHAR)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE contractors (agency VARCHAR, name VARCHAR, id INTEGER)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO staff VALUES (1, 'ann', 'eng'), (2, 'bob', 'ops')`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO contractors VALUES ('acme', 'cid', 3), ('acme', 'ann', 1)`).Error)
+
+	type person struct {
+		ID     int
+		Name   string
+		Dept   *string
+		Agency *string
+	}
+
+	staff := db.Table("staff").Select("id, name, dept").Where("id >= ?", 1)
+	contractors := db.Table("contractors").Select("name, id").Where("agency = ?", "acme")
+
+	var people []person
+	err := db.Table("(?) AS people", duckdb.UnionAllByName(staff, contractors)).
+		Order("id, dept").
+		Scan(&people).Error
+	require.NoError(t, err)
+	require.Len(t, people, 4)
+
+	// Columns line up by name even though the queries select them in different orders
+	assert.Equal(t, person{ID: 1, Name: "ann", Dept: ptr("eng")}, people[0])
+	assert.Equal(t, person{ID: 1, Name: "ann"}, people[1])
+	assert.Equal(t, person{ID: 3, Name: "cid"}, people[3])
+
+	// Without ALL, duplicate rows collapse
+	var names []person
+	err = db.Raw("?", duckdb.UnionByName(
+		db.Table("staff").Select("id, name"),
+		db.Table("contractors").Select("name, id"),
+	)).Scan(&names).Error
+	require.NoError(t, err)
+	assert.Len(t, names, 3)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestArraySliceStep(t *testing.T) {
+	db := setupArrayTestDB(t)
+	require.NoError(t, db.Create(&TestArrayModel{ID: 1, IntArr: duckdb.IntArray{10, 11, 12, 13, 14, 15, 16}}).Error)
+
+	var strided duckdb.IntArray
+	require.NoError(t, db.Model(&TestArrayModel{}).Select("?", duckdb.ArraySliceStep("int_arr", 1, 7, 2)).Row().Scan(&strided))
+	assert.Equal(t, duckdb.IntArray{10, 12, 14, 16}, strided)
+
+	var tail duckdb.IntArray
+	require.NoError(t, db.Model(&TestArrayModel{}).Select("?", duckdb.ArraySliceStep("int_arr", 2, -1, 3)).Row().Scan(&tail))
+	assert.Equal(t, duckdb.IntArray{11, 14}, tail)
+}
+
+func TestListPosition(t *testing.T) {
+	db := setupArrayTestDB(t)
+
+	models := []TestArrayModel{
+		{ID: 1, IntArr: duckdb.IntArray{5, 6, 7}},
+		{ID: 2, IntArr: duckdb.IntArray{7}},
+		{ID: 3, IntArr: duckdb.IntArray{1, 2}},
+		{ID: 4, IntArr: duckdb.IntArray{9, 7}},
+	}
+	for i := range models {
+		require.NoError(t, db.Create(&models[i]).Error)
+	}
+
+	var ordered []TestArrayModel
+	err := db.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:  "? NULLS LAST, id",
+		Vars: []interface{}{duckdb.ListPosition("int_arr", 7)},
+	}}).Find(&ordered).Error
+	require.NoError(t, err)
+
+	ids := make([]uint, len(ordered))
+	for i, m := range ordered {
+		ids[i] = m.ID
+	}
+	assert.Equal(t, []uint{2, 4, 1, 3}, ids)
+
+	type position struct {
+		ID  uint
+		Pos *int
+	}
+	var positions []position
+	err = db.Model(&TestArrayModel{}).
+		Select("id, ? AS pos", duckdb.ListPosition("int_arr", 7)).
+		Order("id").
+		Scan(&positions).Error
+	require.NoError(t, err)
+	require.Len(t, positions, 4)
+	assert.Equal(t, 3, *positions[0].Pos)
+	assert.Nil(t, positions[2].Pos)
+}
+
+func TestListZip(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	type Histogram struct {
+		ID     uint `gorm:"primaryKey"`
+		Bounds duckdb.IntArray
+		Counts duckdb.IntArray
+	}
+	require.NoError(t, db.AutoMigrate(&Histogram{}))
+	require.NoError(t, db.Create(&Histogram{Bounds: duckdb.IntArray{10, 20, 30}, Counts: duckdb.IntArray{4, 7}}).Error)
+
+	var zipped duckdb.ListType
+	require.NoError(t, db.Table("histograms").Select("?", duckdb.ListZip("bounds", "histograms.counts")).Row().Scan(&zipped))
+	assert.Equal(t, duckdb.ListType{
+		map[string]interface{}{"bounds": int64(10), "counts": int64(4)},
+		map[string]interface{}{"bounds": int64(20), "counts": int64(7)},
+		map[string]interface{}{"bounds": int64(30), "counts": nil},
+	}, zipped)
+
+	type bucket struct {
+		Bounds int64
+		Counts *int64
+	}
+	var buckets []bucket
+	err := db.Table("histograms").
+		Select("unnest(?, recursive := true)", duckdb.ListZip("bounds", "counts")).
+		Scan(&buckets).Error
+	require.NoError(t, err)
+	require.Len(t, buckets, 3)
+	assert.Equal(t, int64(20), buckets[1].Bounds)
+	assert.Equal(t, int64(7), *buckets[1].Counts)
+	assert.Nil(t, buckets[2].Counts)
+}
+
+func TestStrftimeStrptime(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	require.NoError(t, db.Exec(`CREATE TABLE orders (id INTEGER, placed_at TIMESTAMP, raw_date VARCHAR)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO orders VALUES
+		(1, '2024-01-03 10:00:00', '03/01/2024'),
+		(2, '2024-01-28 18:30:00', '28/01/2024'),
+		(3, '2024-02-14 09:15:00', '14/02/2024'),
+		(4, '2024-04-01 00:00:00', '01/04/2024')`).Error)
+
+	type bucket struct {
+		Month string
+		Total int
+	}
+	var buckets []bucket
+	err := db.Table("orders").
+		Select("? AS month, COUNT(*) AS total", duckdb.Strftime("placed_at", "%Y-%m")).
+		Group("month").
+		Order("month").
+		Scan(&buckets).Error
+	require.NoError(t, err)
+	assert.Equal(t, []bucket{{"2024-01", 2}, {"2024-02", 1}, {"2024-04", 1}}, buckets)
+
+	var ids []int
+	err = db.Table("orders").
+		Where("? >= ?", duckdb.Strptime("raw_date", "%d/%m/%Y"), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)).
+		Order("id").
+		Pluck("id", &ids).Error
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, ids)
+}
+
+func TestApproximateAggregates(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	require.NoError(t, db.Exec(`CREATE TABLE visits AS
+		SELECT i AS id, 'user_' || (i % 1000) AS visitor, i % 100 AS latency_ms
+		FROM range(20000) t(i)`).Error)
+
+	var exact int64
+	require.NoError(t, db.Table("visits").Select("COUNT(DISTINCT visitor)").Scan(&exact).Error)
+	require.Equal(t, int64(1000), exact)
+
+	var approx int64
+	require.NoError(t, db.Table("visits").Select("?", duckdb.ApproxCountDistinct("visitor")).Scan(&approx).Error)
+	assert.InEpsilon(t, float64(exact), float64(approx), 0.15, "estimate should be within 15%% of the exact count")
+
+	var median float64
+	require.NoError(t, db.Table("visits").Select("?", duckdb.ReservoirQuantile("latency_ms", 0.5)).Scan(&median).Error)
+	assert.InDelta(t, 50, median, 5)
+}
+
+func TestHistogram(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	type Ticket struct {
+		ID       uint `gorm:"primaryKey"`
+		Priority string
+		Severity int
+	}
+	require.NoError(t, db.AutoMigrate(&Ticket{}))
+	for i, priority := range []string{"low", "high", "low", "medium", "low", "high"} {
+		require.NoError(t, db.Create(&Ticket{Priority: priority, Severity: i % 2}).Error)
+	}
+
+	var byPriority duckdb.MapType
+	require.NoError(t, db.Model(&Ticket{}).Select("?", duckdb.Histogram("priority")).Row().Scan(&byPriority))
+	assert.Equal(t, duckdb.MapType{"low": uint64(3), "high": uint64(2), "medium": uint64(1)}, byPriority)
+
+	// Integer buckets come back with string keys
+	var bySeverity duckdb.MapType
+	require.NoError(t, db.Model(&Ticket{}).Where("priority <> ?", "medium").
+		Select("?", duckdb.Histogram("severity")).Row().Scan(&bySeverity))
+	assert.Equal(t, duckdb.MapType{"0": uint64(3), "1": uint64(2)}, bySeverity)
+}
+
+func TestBitstringSketches(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	type PageView struct {
+		ID      uint `gorm:"primaryKey"`
+		Day     string
+		Visitor int64
+	}
+	type DailyVisitors struct {
+		Day    string `gorm:"primaryKey"`
+		Sketch duckdb.BitStringType
+	}
+	require.NoError(t, db.AutoMigrate(&PageView{}, &DailyVisitors{}))
+
+	for visitor := int64(1); visitor <= 30; visitor++ {
+		require.NoError(t, db.Create(&PageView{Day: "mon", Visitor: visitor}).Error)
+		require.NoError(t, db.Create(&PageView{Day: "mon", Visitor: visitor}).Error)
+	}
+	for visitor := int64(21); visitor <= 60; visitor++ {
+		require.NoError(t, db.Create(&PageView{Day: "tue", Visitor: visitor}).Error)
+	}
+
+	var sketches []DailyVisitors
+	require.NoError(t, db.Model(&PageView{}).
+		Select("day, ? AS sketch", duckdb.BitstringAgg("visitor", 1, 100)).
+		Group("day").Order("day").Scan(&sketches).Error)
+	require.Len(t, sketches, 2)
+	assert.Len(t, sketches[0].Sketch.Bits, 100)
+	assert.Equal(t, 30, sketches[0].Sketch.Count())
+
+	for i := range sketches {
+		require.NoError(t, db.Create(&sketches[i]).Error)
+	}
+
+	var tuesday int64
+	require.NoError(t, db.Model(&DailyVisitors{}).Where("day = ?", "tue").
+		Select("?", duckdb.BitstringCount("sketch")).Scan(&tuesday).Error)
+	assert.Equal(t, int64(40), tuesday)
+
+	// Merging the stored daily sketches gives the distinct visitors across both days
+	var merged duckdb.BitStringType
+	require.NoError(t, db.Model(&DailyVisitors{}).Select("?", duckdb.BitstringMerge("sketch")).Scan(&merged).Error)
+	assert.Equal(t, 60, merged.Count())
+}
+
+func TestWindow(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	require.NoError(t, db.Exec(`CREATE TABLE salaries (name VARCHAR, dept VARCHAR, salary INTEGER)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO salaries VALUES
+		('ann', 'eng', 300), ('bob', 'eng', 200), ('cid', 'eng', 100),
+		('dee', 'ops', 150), ('eve', 'ops', 250)`).Error)
+
+	rank := duckdb.AnalyticalFunctionType{FunctionName: "rank", WindowName: "w"}
+	running := duckdb.AnalyticalFunctionType{FunctionName: "sum", Column: "salary", WindowName: "w"}
+
+	type ranked struct {
+		Name    string
+		Rnk     int
+		Running int
+	}
+	var rows []ranked
+	err := db.Table("salaries").
+		Clauses(duckdb.Window("w", "PARTITION BY dept ORDER BY salary DESC")).
+		Select("name, " + rank.ToSQL() + " AS rnk, " + running.ToSQL() + " AS running").
+		Order("dept, rnk").
+		Scan(&rows).Error
+	require.NoError(t, err)
+
+	assert.Equal(t, []ranked{
+		{"ann", 1, 300}, {"bob", 2, 500}, {"cid", 3, 600},
+		{"eve", 1, 250}, {"dee", 2, 400},
+	}, rows)
+
+	// WINDOW is emitted after GROUP BY/HAVING and before ORDER BY
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("salaries").
+			Clauses(duckdb.Window("w", "ORDER BY dept"), duckdb.Window("v", "PARTITION BY dept")).
+			Select("dept, count(*) OVER w, max(dept) OVER v").
+			Group("dept").Having("count(*) > ?", 1).
+			Order("dept").
+			Find(&[]map[string]interface{}{})
+	})
+	assert.Contains(t, sql, `HAVING count(*) > 1 WINDOW "w" AS (ORDER BY dept), "v" AS (PARTITION BY dept) ORDER BY dept`)
+}
+
+func TestListHasAnyAll(t *testing.T) {
+	db := setupArrayTestDB(t)
+
+	models := []TestArrayModel{
+		{StringArr: duckdb.StringArray{"go", "sql", "duckdb"}, IntArr: duckdb.IntArray{1, 2}},
+		{StringArr: duckdb.StringArray{"go"}, IntArr: duckdb.IntArray{3}},
+		{StringArr: duckdb.StringArray{"rust", "sql"}, IntArr: duckdb.IntArray{2, 3}},
+		{StringArr: duckdb.StringArray{"it's"}, IntArr: duckdb.IntArray{}},
+	}
+	for i := range models {
+		require.NoError(t, db.Create(&models[i]).Error)
+	}
+
+	ids := func(expr clause.Expr) []uint {
+		t.Helper()
+		var found []uint
+		require.NoError(t, db.Model(&TestArrayModel{}).Where(expr).Order("id").Pluck("id", &found).Error)
+		return found
+	}
+
+	assert.Equal(t, []uint{1, 3}, ids(duckdb.ListHasAny("string_arr", []string{"sql", "java"})))
+	assert.Equal(t, []uint{1}, ids(duckdb.ListHasAll("string_arr", duckdb.StringArray{"go", "sql"})))
+	assert.Equal(t, []uint{4}, ids(duckdb.ListHasAny("string_arr", []string{"it's"})))
+	assert.Equal(t, []uint{2, 3}, ids(duckdb.ListHasAny("int_arr", []int{3, 9})))
+	assert.Equal(t, []uint{3}, ids(duckdb.ListHasAll("int_arr", duckdb.IntArray{2, 3})))
+	assert.Empty(t, ids(duckdb.ListHasAny("string_arr", []string{"cobol"})))
+}
+
+func TestArrayFilters(t *testing.T) {
+	db := setupArrayTestDB(t)
+
+	models := []TestArrayModel{
+		{StringArr: duckdb.StringArray{"go", "sql", "duckdb"}, IntArr: duckdb.IntArray{1, 2}},
+		{StringArr: duckdb.StringArray{"go"}, IntArr: duckdb.IntArray{3}},
+		{StringArr: duckdb.StringArray{"rust", "sql"}, IntArr: duckdb.IntArray{2, 3}},
+		{StringArr: duckdb.StringArray{"it's"}, IntArr: duckdb.IntArray{}},
+	}
+	for i := range models {
+		require.NoError(t, db.Create(&models[i]).Error)
+	}
+
+	ids := func(query interface{}, args ...interface{}) []uint {
+		t.Helper()
+		var found []uint
+		require.NoError(t, db.Model(&TestArrayModel{}).Where(query, args...).Order("id").Pluck("id", &found).Error)
+		return found
+	}
+
+	assert.Equal(t, []uint{1, 2}, ids(duckdb.ArrayContains("string_arr", "go")))
+	assert.Equal(t, []uint{4}, ids(duckdb.ArrayContains("string_arr", "it's")))
+	assert.Equal(t, []uint{2, 3}, ids(duckdb.ArrayContains("int_arr", 3)))
+	assert.Empty(t, ids(duckdb.ArrayContains("string_arr", "cobol")))
+
+	assert.Equal(t, []uint{1, 3}, ids("? >= ?", duckdb.ArrayLength("string_arr"), 2))
+	assert.Equal(t, []uint{4}, ids("? = ?", duckdb.ArrayLength("int_arr"), 0))
+
+	assert.Equal(t, []uint{1, 3}, ids(duckdb.ArrayOverlaps("string_arr", []string{"sql", "java"})))
+	assert.Equal(t, []uint{1}, ids(duckdb.ArrayHasAll("string_arr", []string{"go", "duckdb"})))
+	assert.Equal(t, []uint{3}, ids(duckdb.ArrayHasAll("int_arr", duckdb.IntArray{3, 2})))
+
+	// Values are bound, never spliced into the SQL
+	stmt := db.Session(&gorm.Session{DryRun: true}).Model(&TestArrayModel{}).
+		Where(duckdb.ArrayContains("string_arr", "x') OR true --")).Find(&[]TestArrayModel{}).Statement
+	assert.Contains(t, stmt.SQL.String(), `list_contains("string_arr", ?)`)
+	assert.Equal(t, []interface{}{"x') OR true --"}, stmt.Vars)
+}
+
+func TestUnnest(t *testing.T) {
+	db := setupArrayTestDB(t)
+
+	models := []TestArrayModel{
+		{StringArr: duckdb.StringArray{"a"}, IntArr: duckdb.IntArray{10, 20}},
+		{StringArr: duckdb.StringArray{"b"}, IntArr: duckdb.IntArray{30}},
+		{StringArr: duckdb.StringArray{"c"}, IntArr: duckdb.IntArray{}},
+	}
+	for i := range models {
+		require.NoError(t, db.Create(&models[i]).Error)
+	}
+
+	type element struct {
+		ID    uint
+		Value int
+	}
+	var elements []element
+	require.NoError(t, db.Model(&TestArrayModel{}).
+		Select("id, ? AS value", duckdb.Unnest("int_arr")).
+		Order("id, value").Scan(&elements).Error)
+	assert.Equal(t, []element{{1, 10}, {1, 20}, {2, 30}}, elements, "empty lists produce no rows")
+
+	// Aggregate over the exploded rows with a subquery
+	var total int
+	require.NoError(t, db.Table("(?) AS elements",
+		db.Model(&TestArrayModel{}).Select("? AS value", duckdb.Unnest("int_arr"))).
+		Select("sum(value)").Scan(&total).Error)
+	assert.Equal(t, 60, total)
+
+	var repeated []element
+	require.NoError(t, db.Model(&TestArrayModel{}).
+		Select("id, ? AS value", duckdb.UnnestSeries(1, 5, 2)).
+		Where("id = ?", 2).Order("value").Scan(&repeated).Error)
+	assert.Equal(t, []element{{2, 1}, {2, 3}, {2, 5}}, repeated)
+}
+
+func TestLatestPerKey(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	type CustomerVersion struct {
+		ID         uint `gorm:"primaryKey"`
+		CustomerID int
+		Email      string
+		Revision   int
+	}
+	require.NoError(t, db.AutoMigrate(&CustomerVersion{}))
+
+	versions := []CustomerVersion{
+		{CustomerID: 1, Email: "a@old.example", Revision: 1},
+		{CustomerID: 2, Email: "b@example", Revision: 1},
+		{CustomerID: 1, Email: "a@new.example", Revision: 3},
+		{CustomerID: 1, Email: "a@mid.example", Revision: 2},
+		{CustomerID: 3, Email: "c@old.example", Revision: 1},
+		{CustomerID: 3, Email: "c@new.example", Revision: 2},
+	}
+	for i := range versions {
+		require.NoError(t, db.Create(&versions[i]).Error)
+	}
+
+	var latest []CustomerVersion
+	require.NoError(t, duckdb.LatestPerKey(db.Model(&CustomerVersion{}).Order("customer_id"), []string{"customer_id"}, "revision", &latest))
+	emails := make([]string, len(latest))
+	for i, v := range latest {
+		emails[i] = v.Email
+	}
+	assert.Equal(t, []string{"a@new.example", "b@example", "c@new.example"}, emails)
+
+	// An explicit direction is kept, here selecting the first version instead
+	var first []CustomerVersion
+	require.NoError(t, duckdb.LatestPerKey(db.Model(&CustomerVersion{}).Order("customer_id"), []string{"customer_id"}, "revision ASC", &first))
+	require.Len(t, first, 3)
+	assert.Equal(t, "a@old.example", first[0].Email)
+	assert.Equal(t, "c@old.example", first[2].Email)
+
+	// A NULLS placement alone still sorts descending
+	var newest []CustomerVersion
+	require.NoError(t, duckdb.LatestPerKey(db.Model(&CustomerVersion{}).Order("customer_id"), []string{"customer_id"}, "revision NULLS LAST", &newest))
+	require.Len(t, newest, 3)
+	assert.Equal(t, "a@new.example", newest[0].Email)
+
+	// Empty terms and unknown modifiers are rejected
+	for _, orderBy := range []string{"", "revision,,id", "revision,", "revision DESC; DROP TABLE customer_versions"} {
+		assert.Error(t, duckdb.LatestPerKey(db.Model(&CustomerVersion{}), []string{"customer_id"}, orderBy, &newest), orderBy)
+	}
+}
+
+func TestTopNPerGroup(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	type Product struct {
+		ID       uint `gorm:"primaryKey"`
+		Category string
+		Name     string
+		Price    float64
+	}
+	require.NoError(t, db.AutoMigrate(&Product{}))
+
+	products := []Product{
+		{Category: "books", Name: "b1", Price: 10},
+		{Category: "books", Name: "b2", Price: 30},
+		{Category: "books", Name: "b3", Price: 20},
+		{Category: "games", Name: "g1", Price: 60},
+		{Category: "games", Name: "g2", Price: 40},
+		{Category: "toys", Name: "t1", Price: 5},
+	}
+	for i := range products {
+		require.NoError(t, db.Create(&products[i]).Error)
+	}
+
+	var top []Product
+	err := duckdb.TopNPerGroup(db.Model(&Product{}).Order("category, price DESC"), []string{"category"}, "price DESC", 2, &top)
+	require.NoError(t, err)
+
+	names := make([]string, len(top))
+	perCategory := map[string]int{}
+	for i, p := range top {
+		names[i] = p.Name
+		perCategory[p.Category]++
+	}
+	assert.Equal(t, map[string]int{"books": 2, "games": 2, "toys": 1}, perCategory)
+	assert.Equal(t, []string{"b2", "b3", "g1", "g2", "t1"}, names)
+
+	// Existing conditions on the query still apply
+	var cheapest []Product
+	err = duckdb.TopNPerGroup(db.Where("price < ?", 50), []string{"category"}, "price asc, id", 1, &cheapest)
+	require.NoError(t, err)
+	assert.Len(t, cheapest, 3)
+
+	err = duckdb.TopNPerGroup(db.Model(&Product{}), []string{"category"}, "price DESC NULLS LAST, id nulls first", 1, &cheapest)
+	require.NoError(t, err)
+	assert.Len(t, cheapest, 3)
+
+	// Anything but a direction and NULLS placement after a column is rejected
+	for _, orderBy := range []string{"price DESC) > 0 OR (1", "price DESC; DROP TABLE products", "price NULLS", "price LIMIT 1", "price DESC ASC"} {
+		err = duckdb.TopNPerGroup(db.Model(&Product{}), []string{"category"}, orderBy, 1, &cheapest)
+		assert.Error(t, err, orderBy)
+	}
+}
+
+func TestQualify(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("events").
+			Clauses(duckdb.Window("w", "PARTITION BY user_id ORDER BY ts DESC")).
+			Clauses(duckdb.Qualify("row_number() OVER w = ?", 1), duckdb.Qualify("ts > ?", "2024-01-01")).
+			Order("user_id").
+			Find(&[]map[string]interface{}{})
+	})
+	assert.Contains(t, sql, `WINDOW "w" AS (PARTITION BY user_id ORDER BY ts DESC) QUALIFY (row_number() OVER w = 1) AND (ts > "2024-01-01") ORDER BY user_id`)
+}
+
+func TestSpatialHelpers(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	origin := duckdb.NewGeometry("POINT(0 0)", 0)
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("places").
+			Where("? <= ?", duckdb.STDistance("location", origin), 5).
+			Where(duckdb.STWithin("location", "POLYGON((0 0, 9 0, 9 9, 0 9, 0 0))")).
+			Where(duckdb.STContains("area", "POINT(1 1)")).
+			Find(&[]map[string]interface{}{})
+	})
+	assert.Contains(t, sql, `ST_Distance("location", ST_GeomFromText("POINT(0 0)")) <= 5`)
+	assert.Contains(t, sql, `ST_Within("location", ST_GeomFromText("POLYGON((0 0, 9 0, 9 9, 0 9, 0 0))"))`)
+	assert.Contains(t, sql, `ST_Contains("area", ST_GeomFromText("POINT(1 1)"))`)
+
+	type Place struct {
+		ID       uint `gorm:"primaryKey"`
+		Name     string
+		Location duckdb.GEOMETRYType
+	}
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Create(&Place{Name: "text", Location: duckdb.NewGeometry("POINT(1 1)", 0)})
+	})
+	assert.NotContains(t, sql, "ST_GeomFromText")
+
+	db, err := gorm.Open(duckdb.New(duckdb.Config{
+		DSN:             ":memory:",
+		SpatialGeometry: true,
+		BootQueries:     []string{"LOAD spatial"},
+	}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Skipf("spatial extension unavailable: %v", err)
+	}
+
+	require.NoError(t, db.AutoMigrate(&Place{}))
+	for name, wkt := range map[string]string{"near": "POINT(3 4)", "edge": "POINT(0 5)", "far": "POINT(10 10)"} {
+		require.NoError(t, db.Create(&Place{Name: name, Location: duckdb.NewGeometry(wkt, 0)}).Error)
+	}
+
+	var names []string
+	require.NoError(t, db.Model(&Place{}).
+		Where("? <= ?", duckdb.STDistance("location", origin), 5).
+		Order("name").Pluck("name", &names).Error)
+	assert.Equal(t, []string{"edge", "near"}, names)
+
+	names = nil
+	require.NoError(t, db.Model(&Place{}).
+		Where(duckdb.STWithin("location", "POLYGON((1 1, 9 1, 9 9, 1 9, 1 1))")).
+		Pluck("name", &names).Error)
+	assert.Equal(t, []string{"near"}, names)
+
+	var wkt string
+	require.NoError(t, db.Model(&Place{}).Select("ST_AsText(location)").Where("name = ?", "far").Scan(&wkt).Error)
+	assert.Equal(t, "POINT (10 10)", wkt)
+}
+
+type PriceQuote struct {
+	ID     uint `gorm:"primaryKey"`
+	Symbol string
+	PriceA float64
+	PriceB float64
+	Volume float64
+}
+
+func TestUpdateColumns(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+	require.NoError(t, db.AutoMigrate(&PriceQuote{}))
+	for _, quote := range []PriceQuote{
+		{Symbol: "AAA", PriceA: 1.234, PriceB: 5.678, Volume: 10.55},
+		{Symbol: "BBB", PriceA: 2.345, PriceB: 6.789, Volume: 20.55},
+	} {
+		require.NoError(t, db.Create(&quote).Error)
+	}
+
+	result := db.Model(&PriceQuote{}).Where("symbol = ?", "AAA").Updates(duckdb.UpdateColumns("^price_", "round(?, 1)"))
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(1), result.RowsAffected)
+
+	var quotes []PriceQuote
+	require.NoError(t, db.Order("id").Find(&quotes).Error)
+	require.Len(t, quotes, 2)
+	assert.InDelta(t, 1.2, quotes[0].PriceA, 1e-9)
+	assert.InDelta(t, 5.7, quotes[0].PriceB, 1e-9)
+	assert.InDelta(t, 10.55, quotes[0].Volume, 1e-9, "non-matching columns are untouched")
+	assert.InDelta(t, 2.345, quotes[1].PriceA, 1e-9, "rows outside the WHERE are untouched")
+
+	// The template may reference the column more than once
+	require.NoError(t, db.Model(&PriceQuote{}).Where("symbol = ?", "BBB").
+		Updates(duckdb.UpdateColumns("^price_b$", "? + ?")).Error)
+	require.NoError(t, db.First(&quotes[1], quotes[1].ID).Error)
+	assert.InDelta(t, 13.578, quotes[1].PriceB, 1e-9)
+
+	err := db.Model(&PriceQuote{}).Where("id > ?", 0).Updates(duckdb.UpdateColumns("^missing", "?")).Error
+	assert.ErrorContains(t, err, "no columns")
+}
+
+func TestPivot(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	type QuarterlySale struct {
+		ID      uint `gorm:"primaryKey"`
+		Region  string
+		Quarter string
+		Amount  float64
+	}
+	require.NoError(t, db.AutoMigrate(&QuarterlySale{}))
+	for _, sale := range []QuarterlySale{
+		{Region: "north", Quarter: "Q1", Amount: 10},
+		{Region: "north", Quarter: "Q1", Amount: 5},
+		{Region: "north", Quarter: "Q2", Amount: 7},
+		{Region: "south", Quarter: "Q2", Amount: 3},
+		{Region: "south", Quarter: "Q3", Amount: 8},
+	} {
+		require.NoError(t, db.Create(&sale).Error)
+	}
+
+	pivot := duckdb.Pivot{
+		Table:   "quarterly_sales",
+		On:      "quarter",
+		Values:  []interface{}{"Q1", "Q2"},
+		Using:   "sum(amount)",
+		GroupBy: []string{"region"},
+	}
+	sql, err := pivot.Build(db)
+	require.NoError(t, err)
+	assert.Equal(t, `PIVOT "quarterly_sales" ON "quarter" IN ('Q1', 'Q2') USING sum(amount) GROUP BY "region"`, sql)
+
+	type RegionQuarters struct {
+		Region string
+		Q1     *float64
+		Q2     float64
+	}
+	wide, err := duckdb.PivotInto[RegionQuarters](context.Background(), db, pivot)
+	require.NoError(t, err)
+	require.Len(t, wide, 2)
+	sort.Slice(wide, func(i, j int) bool { return wide[i].Region < wide[j].Region })
+	assert.Equal(t, "north", wide[0].Region)
+	require.NotNil(t, wide[0].Q1)
+	assert.InDelta(t, 15, *wide[0].Q1, 1e-9)
+	assert.InDelta(t, 7, wide[0].Q2, 1e-9)
+	assert.Nil(t, wide[1].Q1, "south has no Q1 sales")
+	assert.InDelta(t, 3, wide[1].Q2, 1e-9)
+
+	// Without Values every quarter becomes a column, read back dynamically
+	pivot.Values = nil
+	rows, err := duckdb.PivotMaps(context.Background(), db, pivot)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		assert.Contains(t, row, "Q3")
+		if row["region"] == "south" {
+			assert.InDelta(t, 8, row["Q3"], 1e-9)
+		}
+	}
+
+	_, err = duckdb.PivotMaps(context.Background(), db, duckdb.Pivot{Table: "quarterly_sales"})
+	assert.Error(t, err)
+}
+
+func TestExplainJSON(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	type Shipment struct {
+		ID     uint `gorm:"primaryKey"`
+		Status string
+		Weight float64
+	}
+	require.NoError(t, db.AutoMigrate(&Shipment{}))
+	for _, status := range []string{"open", "open", "closed"} {
+		require.NoError(t, db.Create(&Shipment{Status: status, Weight: 2}).Error)
+	}
+
+	var leaves []*duckdb.PlanNode
+	var collectLeaves func(node *duckdb.PlanNode)
+	collectLeaves = func(node *duckdb.PlanNode) {
+		if len(node.Children) == 0 {
+			leaves = append(leaves, node)
+		}
+		for _, child := range node.Children {
+			collectLeaves(child)
+		}
+	}
+
+	plan, err := duckdb.ExplainJSON(db.Model(&Shipment{}).Select("status, sum(weight)").Where("weight > ?", 1).Group("status"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, plan.Name)
+	assert.NotEmpty(t, plan.Children, "the aggregate sits above the scan")
+	collectLeaves(plan)
+	require.NotEmpty(t, leaves)
+	assert.Equal(t, "shipments", leaves[0].ExtraInfo["Table"])
+	assert.GreaterOrEqual(t, leaves[0].EstimatedCardinality, int64(0))
+
+	// Raw queries are explained as written
+	plan, err = duckdb.ExplainJSON(db.Raw("SELECT * FROM shipments WHERE status = ?", "open"))
+	require.NoError(t, err)
+	leaves = nil
+	collectLeaves(plan)
+	require.NotEmpty(t, leaves)
+	assert.Equal(t, "shipments", leaves[0].ExtraInfo["Table"])
+}
+
+func TestBulkUpsert(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	type StockLevel struct {
+		Sku       string `gorm:"primaryKey"`
+		Warehouse string
+		Quantity  int
+	}
+	require.NoError(t, db.AutoMigrate(&StockLevel{}))
+
+	initial := []StockLevel{{Sku: "A-1", Warehouse: "north", Quantity: 5}, {Sku: "B-2", Warehouse: "north", Quantity: 7}}
+	inserted, updated, err := duckdb.BulkUpsert(db, &initial, []string{"sku"}, []string{"quantity"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), inserted)
+	assert.Zero(t, updated)
+
+	batch := []StockLevel{
+		{Sku: "A-1", Warehouse: "south", Quantity: 9},
+		{Sku: "C-3", Warehouse: "south", Quantity: 1},
+		{Sku: "D-4", Warehouse: "south", Quantity: 2},
+	}
+	inserted, updated, err = duckdb.BulkUpsert(db, &batch, []string{"sku"}, []string{"quantity"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), inserted)
+	assert.Equal(t, int64(1), updated)
+
+	var a StockLevel
+	require.NoError(t, db.First(&a, "sku = ?", "A-1").Error)
+	assert.Equal(t, 9, a.Quantity)
+	assert.Equal(t, "north", a.Warehouse, "only the listed columns are updated")
+
+	// Without update columns conflicting rows are skipped
+	skip := []StockLevel{{Sku: "B-2", Quantity: 100}, {Sku: "E-5", Quantity: 3}}
+	inserted, updated, err = duckdb.BulkUpsert(db, &skip, []string{"sku"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), inserted)
+	assert.Zero(t, updated)
+
+	var count int64
+	require.NoError(t, db.Model(&StockLevel{}).Count(&count).Error)
+	assert.Equal(t, int64(5), count)
+
+	_, _, err = duckdb.BulkUpsert(db, &skip, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestBulkUpsert_AutoIncrementID(t *testing.T) {
+	db := setupQueryHelperTestDB(t)
+
+	type Stock struct {
+		ID       uint   `gorm:"primaryKey"`
+		Sku      string `gorm:"uniqueIndex"`
+		Quantity int
+	}
+	require.NoError(t, db.AutoMigrate(&Stock{}))
+
+	initial := []Stock{{Sku: "a", Quantity: 1}, {Sku: "b", Quantity: 2}}
+	inserted, updated, err := duckdb.BulkUpsert(db, &initial, []string{"sku"}, []string{"quantity"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), inserted)
+	assert.Zero(t, updated)
+
+	batch := []Stock{{Sku: "a", Quantity: 10}, {Sku: "b", Quantity: 20}, {Sku: "c", Quantity: 30}}
+	inserted, updated, err = duckdb.BulkUpsert(db, &batch, []string{"sku"}, []string{"quantity"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), inserted)
+	assert.Equal(t, int64(2), updated)
+
+	var stocks []Stock
+	require.NoError(t, db.Order("sku").Find(&stocks).Error)
+	require.Len(t, stocks, 3)
+	for i, want := range []int{10, 20, 30} {
+		assert.Equal(t, want, stocks[i].Quantity, stocks[i].Sku)
+		assert.NotZero(t, stocks[i].ID)
+	}
+}
+
+func TestVectorSearch(t *testing.T) {
+	db := setupTestDB(t)
+
+	type Document struct {
+		ID        uint `gorm:"primaryKey"`
+		Title     string
+		Embedding duckdb.VectorType `gorm:"size:3"`
+	}
+	require.NoError(t, db.AutoMigrate(&Document{}))
+	for _, doc := range []Document{
+		{ID: 1, Title: "x axis", Embedding: duckdb.NewVector(1, 0, 0)},
+		{ID: 2, Title: "y axis", Embedding: duckdb.NewVector(0, 1, 0)},
+		{ID: 3, Title: "z axis", Embedding: duckdb.NewVector(0, 0, 1)},
+		{ID: 4, Title: "xy diagonal", Embedding: duckdb.NewVector(1, 1, 0)},
+	} {
+		require.NoError(t, db.Create(&doc).Error)
+	}
+
+	search, err := duckdb.NewVectorSearch(db, "documents", "embedding")
+	require.NoError(t, err)
+
+	titles := func(query []float32, k int) []string {
+		var docs []Document
+		require.NoError(t, search.TopK(query, k, &docs))
+		result := make([]string, len(docs))
+		for i, doc := range docs {
+			result[i] = doc.Title
+		}
+		return result
+	}
+	assert.Equal(t, []string{"x axis", "xy diagonal"}, titles([]float32{1, 0.1, 0}, 2))
+	assert.Equal(t, []string{"z axis"}, titles([]float32{0, 0, 5}, 1))
+	assert.Equal(t, []string{"xy diagonal", "x axis", "y axis"}, titles([]float32{0.5, 0.4, 0.1}, 3))
+
+	var docs []Document
+	assert.Error(t, search.TopK(nil, 1, &docs))
+	assert.Error(t, search.TopK([]float32{1, 0, 0}, 0, &docs))
+
+	_, err = duckdb.NewVectorSearch(db, "documents", "title")
+	assert.Error(t, err)
+	_, err = duckdb.NewVectorSearch(db, "documents", "missing")
+	assert.Error(t, err)
+}
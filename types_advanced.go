@@ -1,13 +1,22 @@
 package duckdb
 
 import (
+	"context"
 	"database/sql/driver"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/marcboeker/go-duckdb/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 // ===== STRUCT TYPES =====
@@ -15,40 +24,108 @@ import (
 // StructType represents a DuckDB STRUCT type - complex nested data with named fields
 type StructType map[string]interface{}
 
-// Value implements driver.Valuer interface for StructType
+// Value implements driver.Valuer interface for StructType. The struct is
+// rendered as DuckDB's struct text, e.g. {'a': {'b': 1}, 'c': [1, 2]}, which
+// casts to the column's STRUCT type with nested maps as STRUCTs and slices as
+// LISTs, so fields stay reachable as data.a.b in SQL. Keys are sorted.
 func (s StructType) Value() (driver.Value, error) {
 	if s == nil {
 		return "NULL", nil
 	}
+	return structLiteral(map[string]interface{}(s))
+}
 
-	if len(s) == 0 {
-		return "{}", nil
+// structLiteral renders value inside DuckDB struct or list text. Strings are
+// quoted with backslash escapes as the cast expects, and values with no
+// STRUCT or LIST counterpart fall back to their JSON encoding as a string.
+func structLiteral(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return quoteStructText(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []byte:
+		return quoteStructText(string(v)), nil
+	case time.Time:
+		return quoteStructText(v.Format("2006-01-02 15:04:05.999999999-07:00")), nil
+	case MapType:
+		// A MAP field takes the map's own {key=value} text
+		text, err := v.Value()
+		if err != nil {
+			return "", err
+		}
+		return text.(string), nil
 	}
 
-	var parts []string
-	for key, value := range s {
-		var valueStr string
-		switch v := value.(type) {
-		case string:
-			valueStr = fmt.Sprintf("'%s'", strings.ReplaceAll(v, "'", "''"))
-		case int, int64, float64, float32:
-			valueStr = fmt.Sprintf("%v", v)
-		case bool:
-			valueStr = strconv.FormatBool(v)
-		case nil:
-			valueStr = "NULL"
-		default:
-			// Fallback to JSON encoding for complex types
-			jsonBytes, err := json.Marshal(v)
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "NULL", nil
+		}
+		return structLiteral(rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if rv.IsNil() {
+			return "NULL", nil
+		}
+		keys := make([]string, 0, rv.Len())
+		for _, key := range rv.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			field, err := structLiteral(rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key())).Interface())
+			if err != nil {
+				return "", fmt.Errorf("struct field %s: %w", key, err)
+			}
+			parts[i] = quoteStructText(key) + ": " + field
+		}
+		return "{" + strings.Join(parts, ", ") + "}", nil
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return "NULL", nil
+		}
+		elements := make([]string, rv.Len())
+		for i := range elements {
+			element, err := structLiteral(rv.Index(i).Interface())
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal struct field %s: %w", key, err)
+				return "", err
 			}
-			valueStr = fmt.Sprintf("'%s'", strings.ReplaceAll(string(jsonBytes), "'", "''"))
+			elements[i] = element
 		}
-		parts = append(parts, fmt.Sprintf("'%s': %s", key, valueStr))
+		return "[" + strings.Join(elements, ", ") + "]", nil
 	}
 
-	return "{" + strings.Join(parts, ", ") + "}", nil
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return "", err
+		}
+		return structLiteral(v)
+	}
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %T: %w", value, err)
+	}
+	return quoteStructText(string(jsonBytes)), nil
+}
+
+// quoteStructText single-quotes s for DuckDB's VARCHAR to STRUCT and LIST
+// casts, escaping backslashes and single quotes
+func quoteStructText(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
 }
 
 // Scan implements sql.Scanner interface for StructType
@@ -134,23 +211,27 @@ func (StructType) GormDataType() string {
 // MapType represents a DuckDB MAP type - key-value pairs with typed keys and values
 type MapType map[string]interface{}
 
-// Value implements driver.Valuer interface for MapType
+// Value implements driver.Valuer interface for MapType. The map is rendered in
+// the {key=value, ...} text form DuckDB casts to any MAP(K, V) column type, with
+// keys in sorted order.
 func (m MapType) Value() (driver.Value, error) {
-	if m == nil {
-		return "MAP {}", nil
+	if len(m) == 0 {
+		return "{}", nil
 	}
 
-	if len(m) == 0 {
-		return "MAP {}", nil
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	var pairs []string
-	for key, value := range m {
+	pairs := make([]string, 0, len(m))
+	for _, key := range keys {
 		var valueStr string
-		switch v := value.(type) {
+		switch v := m[key].(type) {
 		case string:
-			valueStr = fmt.Sprintf("'%s'", strings.ReplaceAll(v, "'", "''"))
-		case int, int64, float64, float32:
+			valueStr = quoteMapText(v)
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
 			valueStr = fmt.Sprintf("%v", v)
 		case bool:
 			valueStr = strconv.FormatBool(v)
@@ -161,12 +242,57 @@ func (m MapType) Value() (driver.Value, error) {
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal map value for key %s: %w", key, err)
 			}
-			valueStr = fmt.Sprintf("'%s'", strings.ReplaceAll(string(jsonBytes), "'", "''"))
+			valueStr = quoteMapText(string(jsonBytes))
 		}
-		pairs = append(pairs, fmt.Sprintf("'%s': %s", key, valueStr))
+		pairs = append(pairs, quoteMapText(key)+"="+valueStr)
 	}
 
-	return "MAP {" + strings.Join(pairs, ", ") + "}", nil
+	return "{" + strings.Join(pairs, ", ") + "}", nil
+}
+
+// quoteMapText double-quotes s for DuckDB's VARCHAR to MAP cast, escaping
+// backslashes and double quotes
+func quoteMapText(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// mapColumnType returns the MAP column type for a MapType field, taken from a
+// gorm:"type:MAP(KEY, VALUE)" tag or a duckdb:"map:KEY,VALUE" tag (e.g.
+// duckdb:"map:VARCHAR,INTEGER") and defaulting to MAP(VARCHAR, VARCHAR). Key
+// and value must be plain types matching columnTypePattern.
+func mapColumnType(field *schema.Field) string {
+	dataType := strings.TrimSpace(field.TagSettings["TYPE"])
+	if len(dataType) > len("MAP()") && strings.EqualFold(dataType[:4], "MAP(") && strings.HasSuffix(dataType, ")") {
+		if key, value, ok := splitMapTypes(dataType[4 : len(dataType)-1]); ok {
+			return fmt.Sprintf("MAP(%s, %s)", key, value)
+		}
+	}
+
+	settings := schema.ParseTagSetting(field.Tag.Get("duckdb"), ";")
+	if key, value, ok := splitMapTypes(settings["MAP"]); ok {
+		return fmt.Sprintf("MAP(%s, %s)", key, value)
+	}
+	return MapType{}.GormDataType()
+}
+
+// splitMapTypes splits "KEY, VALUE" at its first top-level comma, so
+// DECIMAL(p,s) keys stay intact, and reports whether both are plain types
+func splitMapTypes(spec string) (string, string, bool) {
+	depth := 0
+	for i, r := range spec {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				key, value := strings.TrimSpace(spec[:i]), strings.TrimSpace(spec[i+1:])
+				return key, value, columnTypePattern.MatchString(key) && columnTypePattern.MatchString(value)
+			}
+		}
+	}
+	return "", "", false
 }
 
 // Scan implements sql.Scanner interface for MapType
@@ -185,6 +311,13 @@ func (m *MapType) Scan(value interface{}) error {
 		*m = MapType(v)
 		return nil
 	default:
+		// go-duckdb returns MAP columns as duckdb.Map (map[any]any), which
+		// encoding/json cannot marshal; convert any map kind directly
+		if reflect.ValueOf(value).Kind() == reflect.Map {
+			*m = MapType(nativeValue(value).(map[string]interface{}))
+			return nil
+		}
+
 		jsonBytes, err := json.Marshal(value)
 		if err != nil {
 			return fmt.Errorf("cannot scan %T into MapType", value)
@@ -198,6 +331,33 @@ func (m *MapType) Scan(value interface{}) error {
 	}
 }
 
+// nativeValue converts go-duckdb's native LIST and MAP values, and anything
+// nested inside them, into []interface{} and map[string]interface{}. Map keys
+// are rendered with fmt.Sprint; byte slices (BLOB, UUID) are left untouched.
+func nativeValue(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		result := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			result[fmt.Sprint(iter.Key().Interface())] = nativeValue(iter.Value().Interface())
+		}
+		return result
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return value
+		}
+		result := make([]interface{}, rv.Len())
+		for i := range result {
+			result[i] = nativeValue(rv.Index(i).Interface())
+		}
+		return result
+	default:
+		return value
+	}
+}
+
 func (m *MapType) scanFromString(str string) error {
 	str = strings.TrimSpace(str)
 	if str == "NULL" || str == "" || str == "MAP {}" {
@@ -231,10 +391,14 @@ func (m *MapType) scanFromString(str string) error {
 	pairs := strings.Split(str, ",")
 	for _, pair := range pairs {
 		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 {
+			// DuckDB's own {key=value} text form
+			parts = strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		}
 		if len(parts) == 2 {
 			key := strings.Trim(strings.TrimSpace(parts[0]), "'\"")
 			value := strings.TrimSpace(parts[1])
-			if strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+			if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
 				value = value[1 : len(value)-1]
 			}
 			result[key] = value
@@ -301,9 +465,15 @@ func (l *ListType) Scan(value interface{}) error {
 	case []byte:
 		return l.scanFromString(string(v))
 	case []interface{}:
-		*l = ListType(v)
+		*l = ListType(nativeValue(v).([]interface{}))
 		return nil
 	default:
+		// Typed slices and arrays from the driver are converted element by element
+		if kind := reflect.ValueOf(value).Kind(); kind == reflect.Slice || kind == reflect.Array {
+			*l = ListType(nativeValue(value).([]interface{}))
+			return nil
+		}
+
 		jsonBytes, err := json.Marshal(value)
 		if err != nil {
 			return fmt.Errorf("cannot scan %T into ListType", value)
@@ -407,6 +577,11 @@ func (d *DecimalType) Scan(value interface{}) error {
 	case float64:
 		d.Data = fmt.Sprintf("%.10f", v)
 		return nil
+	case duckdb.Decimal:
+		// The driver reports the column's width and scale with each value
+		d.Data = formatDecimal(v)
+		d.Precision, d.Scale = int(v.Width), int(v.Scale)
+		return nil
 	default:
 		d.Data = fmt.Sprintf("%v", value)
 		return nil
@@ -431,6 +606,95 @@ func (d DecimalType) GormDataType() string {
 	return "DECIMAL"
 }
 
+// decimalColumnType returns the column type for a DecimalType field, taken
+// from precision/scale tags or a type:DECIMAL(p,s) tag and defaulting to
+// DECIMAL(18,6)
+func decimalColumnType(field *schema.Field) string {
+	if field.Precision > 0 {
+		return fmt.Sprintf("DECIMAL(%d,%d)", field.Precision, field.Scale)
+	}
+	dataType := strings.ToUpper(strings.TrimSpace(string(field.DataType)))
+	if (strings.HasPrefix(dataType, "DECIMAL(") || strings.HasPrefix(dataType, "NUMERIC(")) &&
+		columnTypePattern.MatchString(dataType) {
+		return dataType
+	}
+	return "DECIMAL(18,6)"
+}
+
+// Rat returns the decimal as an exact rational number. An empty decimal is 0.
+func (d DecimalType) Rat() (*big.Rat, error) {
+	if d.Data == "" {
+		return new(big.Rat), nil
+	}
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(d.Data))
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal '%s'", d.Data)
+	}
+	return r, nil
+}
+
+// Add returns d + other without rounding. Precision and scale of the result
+// follow DuckDB's rules for DECIMAL addition, capped at 38 digits.
+func (d DecimalType) Add(other DecimalType) (DecimalType, error) {
+	return d.arithmetic(other, (*big.Rat).Add, max(d.scale(), other.scale()), d.sumPrecision(other))
+}
+
+// Sub returns d - other without rounding, with the precision and scale of Add
+func (d DecimalType) Sub(other DecimalType) (DecimalType, error) {
+	return d.arithmetic(other, (*big.Rat).Sub, max(d.scale(), other.scale()), d.sumPrecision(other))
+}
+
+// Mul returns d * other without rounding; the scales of the operands add up
+func (d DecimalType) Mul(other DecimalType) (DecimalType, error) {
+	precision := 0
+	if d.Precision > 0 && other.Precision > 0 {
+		precision = min(d.Precision+other.Precision, maxDecimalPrecision)
+	}
+	return d.arithmetic(other, (*big.Rat).Mul, d.scale()+other.scale(), precision)
+}
+
+const maxDecimalPrecision = 38
+
+func (d DecimalType) arithmetic(other DecimalType, op func(z, x, y *big.Rat) *big.Rat, scale, precision int) (DecimalType, error) {
+	x, err := d.Rat()
+	if err != nil {
+		return DecimalType{}, err
+	}
+	y, err := other.Rat()
+	if err != nil {
+		return DecimalType{}, err
+	}
+	result := op(new(big.Rat), x, y)
+	return DecimalType{Data: result.FloatString(scale), Precision: precision, Scale: scale}, nil
+}
+
+// sumPrecision is the precision DuckDB gives the sum of two decimals: enough
+// integer digits for either operand plus a carry, and the larger scale
+func (d DecimalType) sumPrecision(other DecimalType) int {
+	if d.Precision <= 0 || other.Precision <= 0 {
+		return 0
+	}
+	scale := max(d.scale(), other.scale())
+	integer := max(d.Precision-d.scale(), other.Precision-other.scale())
+	return min(integer+scale+1, maxDecimalPrecision)
+}
+
+// scale is Scale, or the number of fractional digits in Data when Scale is
+// unset
+func (d DecimalType) scale() int {
+	if d.Scale > 0 {
+		return d.Scale
+	}
+	data := strings.TrimSpace(d.Data)
+	if i := strings.IndexAny(data, "eE"); i >= 0 {
+		data = data[:i]
+	}
+	if i := strings.IndexByte(data, '.'); i >= 0 {
+		return len(data) - i - 1
+	}
+	return 0
+}
+
 // ===== INTERVAL TYPES =====
 
 // IntervalType represents a DuckDB INTERVAL type for time calculations
@@ -459,6 +723,12 @@ func NewInterval(years, months, days, hours, minutes, seconds, micros int) Inter
 
 // Value implements driver.Valuer interface for IntervalType
 func (i IntervalType) Value() (driver.Value, error) {
+	return "INTERVAL '" + i.literal() + "'", nil
+}
+
+// literal renders the interval body (e.g. "1 DAY 2 HOUR") without the INTERVAL
+// keyword, suitable for binding as a parameter cast with ?::INTERVAL.
+func (i IntervalType) literal() string {
 	var parts []string
 
 	if i.Years != 0 {
@@ -484,10 +754,10 @@ func (i IntervalType) Value() (driver.Value, error) {
 	}
 
 	if len(parts) == 0 {
-		return "INTERVAL '0 SECOND'", nil
+		return "0 SECOND"
 	}
 
-	return "INTERVAL '" + strings.Join(parts, " ") + "'", nil
+	return strings.Join(parts, " ")
 }
 
 // Scan implements sql.Scanner interface for IntervalType
@@ -504,6 +774,9 @@ func (i *IntervalType) Scan(value interface{}) error {
 		return i.parseInterval(string(v))
 	case time.Duration:
 		return i.fromDuration(v)
+	case duckdb.Interval:
+		i.fromDriver(v)
+		return nil
 	default:
 		return fmt.Errorf("cannot scan %T into IntervalType", value)
 	}
@@ -523,9 +796,16 @@ func (i *IntervalType) parseInterval(str string) error {
 	// Reset all fields
 	*i = IntervalType{}
 
-	// Simple parsing - could be enhanced for complex formats
+	// DuckDB prints intervals as units followed by a clock, e.g.
+	// "1 year 2 months 3 days 04:05:06.5" or "-1 day -02:00:00"
 	parts := strings.Fields(str)
-	for j := 0; j < len(parts)-1; j += 2 {
+	for j := 0; j < len(parts); j++ {
+		if strings.Contains(parts[j], ":") {
+			if err := i.parseClock(parts[j]); err != nil {
+				return err
+			}
+			continue
+		}
 		if j+1 >= len(parts) {
 			break
 		}
@@ -534,12 +814,13 @@ func (i *IntervalType) parseInterval(str string) error {
 		if err != nil {
 			continue
 		}
+		j++
 
-		unit := strings.ToUpper(parts[j+1])
+		unit := strings.ToUpper(parts[j])
 		switch unit {
 		case "YEAR", "YEARS":
 			i.Years = value
-		case "MONTH", "MONTHS":
+		case "MONTH", "MONTHS", "MON", "MONS":
 			i.Months = value
 		case "DAY", "DAYS":
 			i.Days = value
@@ -557,6 +838,51 @@ func (i *IntervalType) parseInterval(str string) error {
 	return nil
 }
 
+// parseClock reads the [-]HH:MM:SS[.ffffff] part of an interval. Hours are not
+// limited to 24 and a leading minus applies to every component.
+func (i *IntervalType) parseClock(clock string) error {
+	sign := 1
+	if rest, ok := strings.CutPrefix(clock, "-"); ok {
+		sign, clock = -1, rest
+	}
+
+	fields := strings.Split(clock, ":")
+	if len(fields) != 3 {
+		return fmt.Errorf("invalid interval time '%s'", clock)
+	}
+	seconds, fraction, _ := strings.Cut(fields[2], ".")
+	if len(fraction) > 6 {
+		fraction = fraction[:6]
+	}
+	fraction += strings.Repeat("0", 6-len(fraction))
+
+	var values [4]int
+	for k, text := range []string{fields[0], fields[1], seconds, fraction} {
+		value, err := strconv.Atoi(text)
+		if err != nil {
+			return fmt.Errorf("invalid interval time '%s'", clock)
+		}
+		values[k] = sign * value
+	}
+	i.Hours, i.Minutes, i.Seconds, i.Micros = values[0], values[1], values[2], values[3]
+	return nil
+}
+
+// fromDriver splits an interval column value, which the driver returns as
+// months, days and microseconds
+func (i *IntervalType) fromDriver(v duckdb.Interval) {
+	micros := v.Micros
+	*i = IntervalType{
+		Years:   int(v.Months / 12),
+		Months:  int(v.Months % 12),
+		Days:    int(v.Days),
+		Hours:   int(micros / int64(time.Hour/time.Microsecond)),
+		Minutes: int(micros / int64(time.Minute/time.Microsecond) % 60),
+		Seconds: int(micros / int64(time.Second/time.Microsecond) % 60),
+		Micros:  int(micros % int64(time.Second/time.Microsecond)),
+	}
+}
+
 func (i *IntervalType) fromDuration(d time.Duration) error {
 	// Convert duration to interval components
 	total := int64(d)
@@ -609,15 +935,33 @@ func NewUUID(uuid string) UUIDType {
 	return UUIDType{Data: uuid}
 }
 
-// Value implements driver.Valuer interface for UUIDType
+// NewUUIDFromBytes creates a UUIDType from the 16 bytes of a binary UUID
+func NewUUIDFromBytes(b [16]byte) UUIDType {
+	return UUIDType{Data: formatUUID(b)}
+}
+
+// Value implements driver.Valuer interface for UUIDType. The UUID is checked
+// and normalized to lowercase 8-4-4-4-12 form; braces, a urn:uuid: prefix and
+// missing hyphens are accepted.
 func (u UUIDType) Value() (driver.Value, error) {
 	if u.Data == "" {
 		return nil, nil
 	}
-	return u.Data, nil
+	b, err := parseUUID(u.Data)
+	if err != nil {
+		return nil, err
+	}
+	return formatUUID(b), nil
+}
+
+// Bytes returns the 16 bytes of the UUID, or zeros when Data is not a UUID
+func (u UUIDType) Bytes() [16]byte {
+	b, _ := parseUUID(u.Data)
+	return b
 }
 
-// Scan implements sql.Scanner interface for UUIDType
+// Scan implements sql.Scanner interface for UUIDType. Binary UUIDs of 16 bytes
+// are stored in canonical text form.
 func (u *UUIDType) Scan(value interface{}) error {
 	if value == nil {
 		u.Data = ""
@@ -629,14 +973,54 @@ func (u *UUIDType) Scan(value interface{}) error {
 		u.Data = v
 		return nil
 	case []byte:
+		if len(v) == 16 {
+			u.Data = formatUUID([16]byte(v))
+			return nil
+		}
 		u.Data = string(v)
 		return nil
+	case [16]byte:
+		u.Data = formatUUID(v)
+		return nil
+	case duckdb.UUID:
+		u.Data = formatUUID(v)
+		return nil
 	default:
 		u.Data = fmt.Sprintf("%v", value)
 		return nil
 	}
 }
 
+// parseUUID reads the 32 hex digits of a UUID in canonical form, optionally
+// wrapped in braces or prefixed with urn:uuid:, or without hyphens
+func parseUUID(s string) ([16]byte, error) {
+	var b [16]byte
+	text := strings.TrimSpace(s)
+	if len(text) > 9 && strings.EqualFold(text[:9], "urn:uuid:") {
+		text = text[9:]
+	} else if strings.HasPrefix(text, "{") && strings.HasSuffix(text, "}") {
+		text = text[1 : len(text)-1]
+	}
+	if len(text) == 36 {
+		if text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+			return b, fmt.Errorf("invalid UUID '%s'", s)
+		}
+		text = text[:8] + text[9:13] + text[14:18] + text[19:23] + text[24:]
+	}
+	if len(text) != 32 {
+		return b, fmt.Errorf("invalid UUID '%s'", s)
+	}
+	if _, err := hex.Decode(b[:], []byte(text)); err != nil {
+		return b, fmt.Errorf("invalid UUID '%s'", s)
+	}
+	return b, nil
+}
+
+func formatUUID(b [16]byte) string {
+	text := hex.EncodeToString(b[:])
+	return text[:8] + "-" + text[8:12] + "-" + text[12:16] + "-" + text[16:20] + "-" + text[20:]
+}
+
 // String returns the UUID as a string
 func (u UUIDType) String() string {
 	return u.Data
@@ -876,7 +1260,10 @@ func (UNIONType) GormDataType() string {
 
 // ===== TIMEZONE AWARE TIMESTAMPS =====
 
-// TimestampTZType represents a DuckDB TIMESTAMPTZ (timestamp with timezone)
+// TimestampTZType represents a DuckDB TIMESTAMPTZ (timestamp with timezone).
+// DuckDB stores the instant only, so the zone is not read back: Scan keeps a
+// named Location already set on the value (see SetZone) and otherwise takes
+// the driver's, and In converts a scanned value to the zone it was written in.
 type TimestampTZType struct {
 	Time     time.Time      `json:"time"`     // The timestamp
 	Location *time.Location `json:"location"` // Timezone information
@@ -897,7 +1284,11 @@ func (t TimestampTZType) Value() (driver.Value, error) {
 	}
 
 	// Return timestamp in the specific timezone
-	return t.Time.In(t.Location).Format("2006-01-02 15:04:05.999999-07:00"), nil
+	ts := t.Time
+	if t.Location != nil {
+		ts = ts.In(t.Location)
+	}
+	return ts.Format("2006-01-02 15:04:05.999999-07:00"), nil
 }
 
 // Scan implements sql.Scanner interface for TimestampTZType
@@ -910,8 +1301,7 @@ func (t *TimestampTZType) Scan(value interface{}) error {
 
 	switch v := value.(type) {
 	case time.Time:
-		t.Time = v
-		t.Location = v.Location()
+		t.setScanned(v)
 		return nil
 	case string:
 		parsedTime, err := time.Parse("2006-01-02 15:04:05.999999-07:00", v)
@@ -921,8 +1311,7 @@ func (t *TimestampTZType) Scan(value interface{}) error {
 				return fmt.Errorf("failed to parse timestamp: %w", err)
 			}
 		}
-		t.Time = parsedTime
-		t.Location = parsedTime.Location()
+		t.setScanned(parsedTime)
 		return nil
 	case []byte:
 		return t.Scan(string(v))
@@ -931,6 +1320,29 @@ func (t *TimestampTZType) Scan(value interface{}) error {
 	}
 }
 
+// setScanned stores a scanned instant, re-applying a named zone set before the
+// scan. Unnamed fixed offsets are not kept, since they would not follow DST.
+func (t *TimestampTZType) setScanned(v time.Time) {
+	if t.Location != nil && t.Location.String() != "" {
+		t.Time = v.In(t.Location)
+		return
+	}
+	t.Time = v
+	t.Location = v.Location()
+}
+
+// SetZone moves the timestamp to the IANA zone name (e.g. "America/New_York"),
+// keeping the instant. The zone is also re-applied by later scans.
+func (t *TimestampTZType) SetZone(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid time zone %q: %w", name, err)
+	}
+	t.Time = t.Time.In(loc)
+	t.Location = loc
+	return nil
+}
+
 // UTC returns the timestamp in UTC
 func (t TimestampTZType) UTC() time.Time {
 	return t.Time.UTC()
@@ -951,9 +1363,13 @@ func (TimestampTZType) GormDataType() string {
 
 // ===== HUGE INTEGER TYPES =====
 
-// HugeIntType represents a DuckDB HUGEINT (128-bit integer)
+// HugeIntType represents a DuckDB HUGEINT (128-bit integer). Unsigned makes
+// it a UHUGEINT; model columns need gorm:"type:UHUGEINT" for that, as gorm
+// takes the column type from a zero value. go-duckdb cannot read UHUGEINT, so
+// select such columns cast to VARCHAR.
 type HugeIntType struct {
-	Data *big.Int `json:"data"` // 128-bit integer value
+	Data     *big.Int `json:"data"` // 128-bit integer value
+	Unsigned bool     `json:"unsigned,omitempty"`
 }
 
 // NewHugeInt creates a new HugeIntType from various sources
@@ -983,6 +1399,9 @@ func (h HugeIntType) Value() (driver.Value, error) {
 	if h.Data == nil {
 		return nil, nil
 	}
+	if h.Unsigned && h.Data.Sign() < 0 {
+		return nil, fmt.Errorf("negative value %s for UHUGEINT", h.Data)
+	}
 
 	return h.Data.String(), nil
 }
@@ -999,9 +1418,20 @@ func (h *HugeIntType) Scan(value interface{}) error {
 	}
 
 	switch v := value.(type) {
+	case *big.Int:
+		// go-duckdb returns HUGEINT columns as *big.Int; copy it rather than
+		// keep the driver's value
+		h.Data = new(big.Int).Set(v)
+		return nil
+	case big.Int:
+		h.Data = new(big.Int).Set(&v)
+		return nil
 	case int64:
 		h.Data.SetInt64(v)
 		return nil
+	case uint64:
+		h.Data.SetUint64(v)
+		return nil
 	case string:
 		if _, ok := h.Data.SetString(v, 10); !ok {
 			return fmt.Errorf("invalid huge integer string: %s", v)
@@ -1038,8 +1468,24 @@ func (h HugeIntType) String() string {
 	return h.Data.String()
 }
 
+// GormValue casts unsigned values in SQL, since go-duckdb cannot bind a
+// parameter of type UHUGEINT.
+func (h HugeIntType) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	value, err := h.Value()
+	if err != nil {
+		_ = db.AddError(err)
+	}
+	if value == nil || !h.Unsigned {
+		return clause.Expr{SQL: "?", Vars: []interface{}{value}}
+	}
+	return clause.Expr{SQL: "CAST(CAST(? AS VARCHAR) AS UHUGEINT)", Vars: []interface{}{value}}
+}
+
 // GormDataType implements the GormDataTypeInterface for HugeIntType
-func (HugeIntType) GormDataType() string {
+func (h HugeIntType) GormDataType() string {
+	if h.Unsigned {
+		return "UHUGEINT"
+	}
 	return "HUGEINT"
 }
 
@@ -1098,6 +1544,15 @@ func (b BitStringType) Value() (driver.Value, error) {
 	return builder.String(), nil
 }
 
+// GormValue binds the bits as VARCHAR and casts them in SQL, since go-duckdb
+// cannot bind a parameter of type BIT.
+func (b BitStringType) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if len(b.Bits) == 0 {
+		return clause.Expr{SQL: "NULL"}
+	}
+	return clause.Expr{SQL: "CAST(CAST(? AS VARCHAR) AS BIT)", Vars: []interface{}{b.ToBinaryString()}}
+}
+
 // Scan implements sql.Scanner interface for BitStringType
 func (b *BitStringType) Scan(value interface{}) error {
 	if value == nil {
@@ -1145,23 +1600,107 @@ func (b BitStringType) ToBinaryString() string {
 	return builder.String()
 }
 
-// ToHexString returns the bit string as hexadecimal representation
+// ToHexString returns the bit string as hexadecimal, as DuckDB's BIT to BLOB
+// cast does: the bits are zero-padded at the front to whole bytes, so "101"
+// is "05" and "101010101" is "0155".
 func (b BitStringType) ToHexString() string {
-	binaryStr := b.ToBinaryString()
+	padding := (8 - len(b.Bits)%8) % 8
+	bytes := make([]byte, (len(b.Bits)+padding)/8)
+	for i, bit := range b.Bits {
+		if bit {
+			position := padding + i
+			bytes[position/8] |= 0x80 >> (position % 8)
+		}
+	}
+	return strings.ToUpper(hex.EncodeToString(bytes))
+}
 
-	// Pad to multiple of 4 for hex conversion
-	for len(binaryStr)%4 != 0 {
-		binaryStr = "0" + binaryStr
+// FromHexString creates a BitStringType from hexadecimal as produced by
+// ToHexString. With length > 0 the bit string keeps the last length bits and
+// the padding bits in front of them must be zero; otherwise every bit of the
+// decoded bytes is kept.
+func FromHexString(hexStr string, length int) (BitStringType, error) {
+	if len(hexStr)%2 == 1 {
+		hexStr = "0" + hexStr
+	}
+	bytes, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return BitStringType{}, fmt.Errorf("invalid hex bit string: %w", err)
 	}
 
-	var hexBuilder strings.Builder
-	for i := 0; i < len(binaryStr); i += 4 {
-		fourBits := binaryStr[i : i+4]
-		val, _ := strconv.ParseInt(fourBits, 2, 8)
-		hexBuilder.WriteString(fmt.Sprintf("%X", val))
+	total := len(bytes) * 8
+	if length <= 0 {
+		length = total
+	}
+	if length > total {
+		return BitStringType{}, fmt.Errorf("hex bit string has %d bits, need %d", total, length)
 	}
 
-	return hexBuilder.String()
+	bits := make([]bool, length)
+	for position := 0; position < total; position++ {
+		set := bytes[position/8]&(0x80>>(position%8)) != 0
+		if i := position - (total - length); i >= 0 {
+			bits[i] = set
+		} else if set {
+			return BitStringType{}, fmt.Errorf("hex bit string does not fit in %d bits", length)
+		}
+	}
+
+	return BitStringType{Bits: bits, Length: length}, nil
+}
+
+// And returns the bitwise AND of two bit strings of the same length
+func (b BitStringType) And(other BitStringType) (BitStringType, error) {
+	return b.combine(other, "AND", func(x, y bool) bool { return x && y })
+}
+
+// Or returns the bitwise OR of two bit strings of the same length
+func (b BitStringType) Or(other BitStringType) (BitStringType, error) {
+	return b.combine(other, "OR", func(x, y bool) bool { return x || y })
+}
+
+// Xor returns the bitwise XOR of two bit strings of the same length
+func (b BitStringType) Xor(other BitStringType) (BitStringType, error) {
+	return b.combine(other, "XOR", func(x, y bool) bool { return x != y })
+}
+
+// combine applies op bit by bit. Like DuckDB, it rejects bit strings of
+// different lengths.
+func (b BitStringType) combine(other BitStringType, name string, op func(x, y bool) bool) (BitStringType, error) {
+	if len(b.Bits) != len(other.Bits) {
+		return BitStringType{}, fmt.Errorf("cannot %s bit strings of different lengths %d and %d", name, len(b.Bits), len(other.Bits))
+	}
+	bits := make([]bool, len(b.Bits))
+	for i := range bits {
+		bits[i] = op(b.Bits[i], other.Bits[i])
+	}
+	return BitStringType{Bits: bits, Length: b.Length}, nil
+}
+
+// ShiftLeft shifts the bits n places towards the front, filling with zeros
+// and keeping the length, as DuckDB's << does
+func (b BitStringType) ShiftLeft(n int) (BitStringType, error) {
+	return b.shift(n, n)
+}
+
+// ShiftRight shifts the bits n places towards the end, filling with zeros
+// and keeping the length, as DuckDB's >> does
+func (b BitStringType) ShiftRight(n int) (BitStringType, error) {
+	return b.shift(n, -n)
+}
+
+// shift moves every bit offset places towards the front (negative: the end)
+func (b BitStringType) shift(n, offset int) (BitStringType, error) {
+	if n < 0 {
+		return BitStringType{}, fmt.Errorf("negative shift count %d", n)
+	}
+	bits := make([]bool, len(b.Bits))
+	for i := range bits {
+		if j := i + offset; j >= 0 && j < len(b.Bits) {
+			bits[i] = b.Bits[j]
+		}
+	}
+	return BitStringType{Bits: bits, Length: b.Length}, nil
 }
 
 // Count returns the number of set bits (1s)
@@ -1347,6 +1886,35 @@ func (g GEOMETRYType) Value() (driver.Value, error) {
 	return g.WKT, nil
 }
 
+// GormValue binds the WKT through ST_GeomFromText when Config.SpatialGeometry
+// is set, as go-duckdb cannot bind a GEOMETRY parameter; otherwise the
+// geometry is stored as text like Value does. Read GEOMETRY columns back with
+// ST_AsText, since their raw value is DuckDB's internal encoding.
+func (g GEOMETRYType) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if g.WKT == "" {
+		return clause.Expr{SQL: "NULL"}
+	}
+	if spatialGeometry(db) {
+		return clause.Expr{SQL: "ST_GeomFromText(?)", Vars: []interface{}{g.WKT}}
+	}
+	value, _ := g.Value()
+	return clause.Expr{SQL: "?", Vars: []interface{}{value}}
+}
+
+// spatialGeometry reports whether db was opened with Config.SpatialGeometry
+func spatialGeometry(db *gorm.DB) bool {
+	if db == nil {
+		return false
+	}
+	switch dialector := db.Dialector.(type) {
+	case *Dialector:
+		return dialector.Config != nil && dialector.SpatialGeometry
+	case *extensionAwareDialector:
+		return dialector.Dialector != nil && dialector.Config != nil && dialector.SpatialGeometry
+	}
+	return false
+}
+
 // Scan implements sql.Scanner interface for GEOMETRYType
 func (g *GEOMETRYType) Scan(value interface{}) error {
 	if value == nil {
@@ -1413,16 +1981,156 @@ func (g GEOMETRYType) IsEmpty() bool {
 	return g.WKT == ""
 }
 
-// GetBounds returns the bounding box of the geometry (simplified implementation)
+// GetBounds returns the bounding box of the geometry as minX, minY, maxX and
+// maxY, plus minZ and maxZ for 3D geometries. The coordinates are read from
+// the WKT; empty or unparseable geometries report zeros.
 func (g GEOMETRYType) GetBounds() map[string]float64 {
-	// This is a simplified implementation
-	// In a real implementation, you would parse the WKT to extract actual bounds
-	return map[string]float64{
-		"minX": 0.0,
-		"minY": 0.0,
-		"maxX": 0.0,
-		"maxY": 0.0,
+	bounds := map[string]float64{"minX": 0, "minY": 0, "maxX": 0, "maxY": 0}
+	rings, hasZ, err := parseWKTCoordinates(g.WKT)
+	if err != nil {
+		return bounds
+	}
+
+	keys := []string{"X", "Y", "Z"}
+	first := true
+	for _, ring := range rings {
+		for _, coord := range ring {
+			for i, key := range keys {
+				if i == 2 && !hasZ {
+					break
+				}
+				if first || coord[i] < bounds["min"+key] {
+					bounds["min"+key] = coord[i]
+				}
+				if first || coord[i] > bounds["max"+key] {
+					bounds["max"+key] = coord[i]
+				}
+			}
+			first = false
+		}
+	}
+	return bounds
+}
+
+// Centroid returns the average of the geometry's vertices as a POINT with the
+// same SRID. A polygon ring's closing vertex is counted once. This matches the
+// true centroid for points, segments and regular shapes only; use DuckDB's
+// ST_Centroid for anything else.
+func (g GEOMETRYType) Centroid() (GEOMETRYType, error) {
+	rings, hasZ, err := parseWKTCoordinates(g.WKT)
+	if err != nil {
+		return GEOMETRYType{}, err
+	}
+
+	var sum [3]float64
+	count := 0
+	for _, ring := range rings {
+		if len(ring) > 1 && equalCoordinates(ring[0], ring[len(ring)-1]) {
+			ring = ring[:len(ring)-1]
+		}
+		for _, coord := range ring {
+			for i := range sum {
+				sum[i] += coord[i]
+			}
+			count++
+		}
+	}
+
+	format := func(f float64) string {
+		return strconv.FormatFloat(f/float64(count), 'f', -1, 64)
 	}
+	wkt := fmt.Sprintf("POINT(%s %s)", format(sum[0]), format(sum[1]))
+	if hasZ {
+		wkt = fmt.Sprintf("POINT Z (%s %s %s)", format(sum[0]), format(sum[1]), format(sum[2]))
+	}
+	return NewGeometry(wkt, g.SRID), nil
+}
+
+func equalCoordinates(a, b [3]float64) bool {
+	return a[0] == b[0] && a[1] == b[1] && a[2] == b[2]
+}
+
+// parseWKTCoordinates reads the coordinate lists of a WKT geometry: one list
+// per innermost parenthesised group, i.e. per point, linestring or polygon
+// ring. hasZ reports a Z ordinate, from a Z/ZM tag or from untagged
+// coordinates with three ordinates; an M ordinate is skipped.
+func parseWKTCoordinates(wkt string) (rings [][][3]float64, hasZ bool, err error) {
+	wkt = strings.TrimSpace(wkt)
+	if i := strings.Index(wkt, ";"); strings.HasPrefix(strings.ToUpper(wkt), "SRID=") && i >= 0 {
+		wkt = wkt[i+1:]
+	}
+	open := strings.IndexByte(wkt, '(')
+	if open < 0 {
+		return nil, false, fmt.Errorf("geometry %q has no coordinates", wkt)
+	}
+
+	tag := strings.ToUpper(wkt[:open])
+	for _, prefix := range []string{"GEOMETRYCOLLECTION", "MULTIPOLYGON", "MULTILINESTRING", "MULTIPOINT", "POLYGON", "LINESTRING", "POINT"} {
+		if strings.HasPrefix(tag, prefix) {
+			tag = strings.TrimSpace(tag[len(prefix):])
+			break
+		}
+	}
+	hasM := tag == "M" || tag == "ZM"
+	hasZ = tag == "Z" || tag == "ZM"
+
+	// Find the innermost groups by tracking, per open parenthesis, whether a
+	// nested group started inside it
+	var starts []int
+	var nested []bool
+	var groups []string
+	for i, ch := range wkt {
+		switch ch {
+		case '(':
+			if len(nested) > 0 {
+				nested[len(nested)-1] = true
+			}
+			starts = append(starts, i+1)
+			nested = append(nested, false)
+		case ')':
+			if len(starts) == 0 {
+				return nil, false, fmt.Errorf("unbalanced parentheses in %q", wkt)
+			}
+			if !nested[len(nested)-1] {
+				groups = append(groups, wkt[starts[len(starts)-1]:i])
+			}
+			starts, nested = starts[:len(starts)-1], nested[:len(nested)-1]
+		}
+	}
+	if len(starts) != 0 {
+		return nil, false, fmt.Errorf("unbalanced parentheses in %q", wkt)
+	}
+
+	for _, group := range groups {
+		var ring [][3]float64
+		for _, point := range strings.Split(group, ",") {
+			fields := strings.Fields(point)
+			if len(fields) < 2 || len(fields) > 4 {
+				return nil, false, fmt.Errorf("invalid coordinate %q", strings.TrimSpace(point))
+			}
+			if tag == "" && len(fields) >= 3 {
+				hasZ = true
+			}
+			var coord [3]float64
+			for i, field := range fields {
+				if i == 2 && !hasZ && hasM {
+					break
+				}
+				if i > 2 {
+					break
+				}
+				if coord[i], err = strconv.ParseFloat(field, 64); err != nil {
+					return nil, false, fmt.Errorf("invalid coordinate %q: %w", strings.TrimSpace(point), err)
+				}
+			}
+			ring = append(ring, coord)
+		}
+		rings = append(rings, ring)
+	}
+	if len(rings) == 0 {
+		return nil, false, fmt.Errorf("geometry %q has no coordinates", wkt)
+	}
+	return rings, hasZ, nil
 }
 
 // IsPoint returns true if the geometry is a POINT
@@ -1726,6 +2434,7 @@ type AnalyticalFunctionType struct {
 	Column       string                 `json:"column"`        // Target column
 	Parameters   map[string]interface{} `json:"parameters"`    // Function parameters
 	WindowFrame  string                 `json:"window_frame"`  // OVER clause details
+	WindowName   string                 `json:"window_name"`   // Named window from a WINDOW clause, see Window
 }
 
 // NewAnalyticalFunction creates a new AnalyticalFunctionType
@@ -1746,6 +2455,9 @@ func (a AnalyticalFunctionType) Value() (driver.Value, error) {
 		"params":   a.Parameters,
 		"window":   a.WindowFrame,
 	}
+	if a.WindowName != "" {
+		functionData["window_name"] = a.WindowName
+	}
 
 	jsonBytes, err := json.Marshal(functionData)
 	if err != nil {
@@ -1762,6 +2474,7 @@ func (a *AnalyticalFunctionType) Scan(value interface{}) error {
 		a.Column = ""
 		a.Parameters = nil
 		a.WindowFrame = ""
+		a.WindowName = ""
 		return nil
 	}
 
@@ -1796,6 +2509,10 @@ func (a *AnalyticalFunctionType) Scan(value interface{}) error {
 		a.WindowFrame = window
 	}
 
+	if windowName, ok := functionData["window_name"].(string); ok {
+		a.WindowName = windowName
+	}
+
 	return nil
 }
 
@@ -1817,6 +2534,11 @@ func (a AnalyticalFunctionType) ToSQL() string {
 		}
 	}
 
+	// Reference a named window (WINDOW clause) in preference to an inline frame
+	if a.WindowName != "" {
+		return fmt.Sprintf("%s OVER %s", baseFunction, a.WindowName)
+	}
+
 	// Add window frame if specified
 	if a.WindowFrame != "" {
 		return fmt.Sprintf("%s OVER (%s)", baseFunction, a.WindowFrame)
@@ -3,10 +3,13 @@ package duckdb_test
 import (
 	"encoding/json"
 	"math/big"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	goduckdb "github.com/marcboeker/go-duckdb/v2"
+
 	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
 )
 
@@ -94,6 +97,54 @@ func TestStructTypeComprehensive(t *testing.T) {
 	})
 }
 
+// TestStructType_Nested checks that nested maps and slices are stored as
+// nested STRUCTs and LISTs that SQL can reach into
+func TestStructType_Nested(t *testing.T) {
+	data := duckdb.StructType{
+		"inner": map[string]interface{}{"x": 7, "label": "it's {odd}, \\ok"},
+		"tags":  []string{"a", "b"},
+		"codes": []interface{}{1, nil, 3},
+		"note":  nil,
+	}
+	val, err := data.Value()
+	if err != nil {
+		t.Fatalf("Value() failed: %v", err)
+	}
+	want := `{'codes': [1, NULL, 3], 'inner': {'label': 'it\'s {odd}, \\ok', 'x': 7}, 'note': NULL, 'tags': ['a', 'b']}`
+	if val != want {
+		t.Errorf("Expected %s, got %v", want, val)
+	}
+
+	db := setupTestDB(t)
+	if err := db.Exec(`CREATE TABLE shipments (id INTEGER, data STRUCT("inner" STRUCT(x INTEGER, label VARCHAR), tags VARCHAR[], codes INTEGER[], note VARCHAR))`).Error; err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	if err := db.Exec("INSERT INTO shipments VALUES (?, ?)", 1, data).Error; err != nil {
+		t.Fatalf("Failed to insert nested struct: %v", err)
+	}
+
+	var row struct {
+		X      int
+		Label  string
+		Second string
+	}
+	if err := db.Raw(`SELECT data.inner.x AS x, data.inner.label AS label, data.tags[2] AS second FROM shipments`).Scan(&row).Error; err != nil {
+		t.Fatalf("Failed to query inner fields: %v", err)
+	}
+	if row.X != 7 || row.Label != "it's {odd}, \\ok" || row.Second != "b" {
+		t.Errorf("Unexpected inner fields: %+v", row)
+	}
+
+	var stored duckdb.StructType
+	if err := db.Raw("SELECT data FROM shipments").Row().Scan(&stored); err != nil {
+		t.Fatalf("Failed to read struct: %v", err)
+	}
+	inner, ok := stored["inner"].(map[string]interface{})
+	if !ok || inner["x"] != int32(7) {
+		t.Errorf("Expected a nested struct, got %#v", stored["inner"])
+	}
+}
+
 // TestMapTypeComprehensive tests all code paths for MapType
 func TestMapTypeComprehensive(t *testing.T) {
 	t.Run("Value_NilMap", func(t *testing.T) {
@@ -102,8 +153,8 @@ func TestMapTypeComprehensive(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
-		if val != "MAP {}" {
-			t.Errorf("Expected 'MAP {}', got %v", val)
+		if val != "{}" {
+			t.Errorf("Expected '{}', got %v", val)
 		}
 	})
 
@@ -113,8 +164,8 @@ func TestMapTypeComprehensive(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
-		if val != "MAP {}" {
-			t.Errorf("Expected 'MAP {}', got %v", val)
+		if val != "{}" {
+			t.Errorf("Expected '{}', got %v", val)
 		}
 	})
 
@@ -207,6 +258,68 @@ func TestListTypeComprehensive(t *testing.T) {
 	})
 }
 
+// TestNativeDriverValues tests scanning go-duckdb's native LIST/MAP values
+func TestNativeDriverValues(t *testing.T) {
+	t.Run("MapType_DriverMap", func(t *testing.T) {
+		var m duckdb.MapType
+		if err := m.Scan(goduckdb.Map{int32(1): "one", int32(2): []interface{}{"a", "b"}}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if m["1"] != "one" {
+			t.Errorf("Expected key '1' to map to 'one', got %v", m["1"])
+		}
+		if list, ok := m["2"].([]interface{}); !ok || len(list) != 2 {
+			t.Errorf("Expected nested list under key '2', got %#v", m["2"])
+		}
+	})
+
+	t.Run("ListType_TypedSlice", func(t *testing.T) {
+		var l duckdb.ListType
+		if err := l.Scan([]int32{4, 5, 6}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(l) != 3 || l[0] != int32(4) || l[2] != int32(6) {
+			t.Errorf("Expected [4 5 6], got %#v", l)
+		}
+	})
+
+	t.Run("ListType_NestedDriverMaps", func(t *testing.T) {
+		var l duckdb.ListType
+		if err := l.Scan([]interface{}{goduckdb.Map{"k": int64(1)}, nil}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		entry, ok := l[0].(map[string]interface{})
+		if !ok || entry["k"] != int64(1) {
+			t.Errorf("Expected nested map converted to map[string]interface{}, got %#v", l[0])
+		}
+		if l[1] != nil {
+			t.Errorf("Expected nil element preserved, got %#v", l[1])
+		}
+	})
+
+	t.Run("Database_MapAndListColumns", func(t *testing.T) {
+		db := setupQueryHelperTestDB(t)
+
+		var row struct {
+			Attrs duckdb.MapType
+			Items duckdb.ListType
+		}
+		err := db.Raw("SELECT MAP {10: 'ten', 20: 'twenty'} AS attrs, [MAP {'x': 1}, MAP {'y': 2}] AS items").Scan(&row).Error
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if row.Attrs["10"] != "ten" || row.Attrs["20"] != "twenty" {
+			t.Errorf("Unexpected map contents: %#v", row.Attrs)
+		}
+		if len(row.Items) != 2 {
+			t.Fatalf("Expected 2 list items, got %#v", row.Items)
+		}
+		if first, ok := row.Items[0].(map[string]interface{}); !ok || first["x"] != int32(1) {
+			t.Errorf("Unexpected first item: %#v", row.Items[0])
+		}
+	})
+}
+
 // TestDecimalTypeComprehensive tests all code paths for DecimalType
 func TestDecimalTypeComprehensive(t *testing.T) {
 	t.Run("Value_EmptyData", func(t *testing.T) {
@@ -339,6 +452,60 @@ func TestIntervalTypeComprehensive(t *testing.T) {
 	})
 }
 
+// TestIntervalType_ScanDuckDBOutput checks intervals as DuckDB prints them,
+// both captured strings and values read back from the database
+func TestIntervalType_ScanDuckDBOutput(t *testing.T) {
+	cases := map[string]duckdb.IntervalType{
+		"1 day 02:00:00":                  {Days: 1, Hours: 2},
+		"1 year 2 months":                 {Years: 1, Months: 2},
+		"00:30:00":                        {Minutes: 30},
+		"3 days 04:05:06":                 {Days: 3, Hours: 4, Minutes: 5, Seconds: 6},
+		"00:00:01.5":                      {Seconds: 1, Micros: 500000},
+		"-1 day -02:00:00":                {Days: -1, Hours: -2},
+		"1 year 2 months 40:00:00.000007": {Years: 1, Months: 2, Hours: 40, Micros: 7},
+		"100000:00:00":                    {Hours: 100000},
+		"00:00:00":                        {},
+		"INTERVAL '3 DAYS 1 HOUR'":        {Days: 3, Hours: 1},
+	}
+	for text, want := range cases {
+		var got duckdb.IntervalType
+		if err := got.Scan(text); err != nil {
+			t.Errorf("Scan(%q) failed: %v", text, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Scan(%q) = %+v, want %+v", text, got, want)
+		}
+	}
+
+	var bad duckdb.IntervalType
+	if err := bad.Scan("1 day 02:xx:00"); err == nil {
+		t.Error("Expected error for a malformed time")
+	}
+
+	var native duckdb.IntervalType
+	if err := native.Scan(goduckdb.Interval{Months: 14, Days: 3, Micros: 3723000004}); err != nil {
+		t.Fatalf("Scan of driver interval failed: %v", err)
+	}
+	if want := duckdb.NewInterval(1, 2, 3, 1, 2, 3, 4); native != want {
+		t.Errorf("Expected %+v, got %+v", want, native)
+	}
+
+	db := setupTestDB(t)
+	for _, literal := range []string{"1 day 2 hours", "3 days 4 hours 5 minutes 6.25 seconds", "-90 minutes"} {
+		var asText, asInterval duckdb.IntervalType
+		if err := db.Raw("SELECT CAST(INTERVAL (?) AS VARCHAR)", literal).Row().Scan(&asText); err != nil {
+			t.Fatalf("Scanning %q as text failed: %v", literal, err)
+		}
+		if err := db.Raw("SELECT INTERVAL (?)", literal).Row().Scan(&asInterval); err != nil {
+			t.Fatalf("Scanning %q as interval failed: %v", literal, err)
+		}
+		if asText != asInterval || asText.ToDuration() == 0 {
+			t.Errorf("%q: text form %+v and interval form %+v differ", literal, asText, asInterval)
+		}
+	}
+}
+
 // TestUUIDTypeComprehensive tests all code paths for UUIDType
 func TestUUIDTypeComprehensive(t *testing.T) {
 	t.Run("Value_EmptyUUID", func(t *testing.T) {
@@ -397,6 +564,78 @@ func TestUUIDTypeComprehensive(t *testing.T) {
 			t.Fatalf("Expected no error for UUIDType conversion, got %v", err)
 		}
 	})
+
+	t.Run("Value_Normalizes", func(t *testing.T) {
+		for _, text := range []string{
+			"550E8400-E29B-41D4-A716-446655440000",
+			"{550e8400-e29b-41d4-a716-446655440000}",
+			"urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+			"550e8400e29b41d4a716446655440000",
+		} {
+			val, err := duckdb.NewUUID(text).Value()
+			if err != nil {
+				t.Errorf("Value() of %q failed: %v", text, err)
+			} else if val != "550e8400-e29b-41d4-a716-446655440000" {
+				t.Errorf("Value() of %q = %v", text, val)
+			}
+		}
+	})
+
+	t.Run("Value_Invalid", func(t *testing.T) {
+		for _, text := range []string{
+			"not-a-uuid",
+			"550e8400-e29b-41d4-a716-44665544000",
+			"550e8400-e29b-41d4-a716-44665544000g",
+			"550e8400e-29b-41d4-a716-446655440000",
+		} {
+			if _, err := duckdb.NewUUID(text).Value(); err == nil {
+				t.Errorf("Expected error for %q", text)
+			}
+		}
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		raw := [16]byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00}
+		u := duckdb.NewUUIDFromBytes(raw)
+		if u.String() != "550e8400-e29b-41d4-a716-446655440000" {
+			t.Errorf("Expected canonical text, got %s", u)
+		}
+		if u.Bytes() != raw {
+			t.Errorf("Bytes() = %x", u.Bytes())
+		}
+
+		var scanned duckdb.UUIDType
+		if err := scanned.Scan(raw[:]); err != nil {
+			t.Fatalf("Scan of 16 bytes failed: %v", err)
+		}
+		if scanned != u {
+			t.Errorf("Expected %s, got %s", u, scanned)
+		}
+		if duckdb.NewUUID("bogus").Bytes() != [16]byte{} {
+			t.Error("Expected zero bytes for an invalid UUID")
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		db := setupTestDB(t)
+		if err := db.Exec("CREATE TABLE tokens (id UUID)").Error; err != nil {
+			t.Fatalf("Failed to create table: %v", err)
+		}
+		if err := db.Exec("INSERT INTO tokens VALUES (?)", duckdb.NewUUID("{550E8400-E29B-41D4-A716-446655440000}")).Error; err != nil {
+			t.Fatalf("Failed to insert UUID: %v", err)
+		}
+		if err := db.Exec("INSERT INTO tokens VALUES (?)", duckdb.NewUUID("nope")).Error; err == nil {
+			t.Error("Expected an invalid UUID to be rejected")
+		}
+
+		var stored duckdb.UUIDType
+		if err := db.Raw("SELECT id FROM tokens").Row().Scan(&stored); err != nil {
+			t.Fatalf("Failed to read UUID: %v", err)
+		}
+		if stored.String() != "550e8400-e29b-41d4-a716-446655440000" {
+			t.Errorf("Expected canonical UUID, got %q", stored.String())
+		}
+	})
 }
 
 // TestJSONTypeComprehensive tests all code paths for JSONType
@@ -702,6 +941,65 @@ func TestTimestampTZTypeComprehensive(t *testing.T) {
 			t.Error("Expected error for invalid type")
 		}
 	})
+
+	t.Run("Zone_AcrossDST", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Skipf("time zone database unavailable: %v", err)
+		}
+		db := setupTestDB(t)
+
+		type DSTEvent struct {
+			ID uint `gorm:"primaryKey"`
+			At duckdb.TimestampTZType
+		}
+		if err := db.AutoMigrate(&DSTEvent{}); err != nil {
+			t.Fatalf("Failed to migrate: %v", err)
+		}
+
+		// Clocks in New York jump from 02:00 EST to 03:00 EDT on 2024-03-10
+		before := duckdb.NewTimestampTZ(time.Date(2024, 3, 10, 1, 30, 0, 0, newYork), newYork)
+		after := duckdb.NewTimestampTZ(time.Date(2024, 3, 10, 3, 30, 0, 0, newYork), newYork)
+		events := []DSTEvent{{At: before}, {At: after}}
+		if err := db.Create(&events).Error; err != nil {
+			t.Fatalf("Failed to create: %v", err)
+		}
+
+		var found []DSTEvent
+		if err := db.Order("id").Find(&found).Error; err != nil {
+			t.Fatalf("Failed to read back: %v", err)
+		}
+		for i, event := range found {
+			if !event.At.Time.Equal(events[i].At.Time) {
+				t.Errorf("Expected instant %v, got %v", events[i].At.Time, event.At.Time)
+			}
+			local := event.At.In(newYork)
+			if got, want := local.Time.Format("15:04 MST"), events[i].At.Time.Format("15:04 MST"); got != want {
+				t.Errorf("Expected wall clock %s, got %s", want, got)
+			}
+		}
+		if got := found[1].At.Time.Sub(found[0].At.Time); got != time.Hour {
+			t.Errorf("Expected one hour between events, got %v", got)
+		}
+
+		var scanned duckdb.TimestampTZType
+		if err := scanned.SetZone("America/New_York"); err != nil {
+			t.Fatalf("SetZone failed: %v", err)
+		}
+		if err := db.Raw(`SELECT "at" FROM dst_events WHERE id = ?`, found[0].ID).Row().Scan(&scanned); err != nil {
+			t.Fatalf("Failed to scan: %v", err)
+		}
+		if scanned.Location.String() != "America/New_York" {
+			t.Errorf("Expected zone to be kept, got %s", scanned.Location)
+		}
+		if got := scanned.Time.Add(time.Hour).Format("15:04 MST"); got != "03:30 EDT" {
+			t.Errorf("Expected 03:30 EDT an hour later, got %s", got)
+		}
+
+		if err := scanned.SetZone("Not/AZone"); err == nil {
+			t.Error("Expected error for unknown zone")
+		}
+	})
 }
 
 // TestHugeIntTypeComprehensive tests all code paths for HugeIntType
@@ -818,6 +1116,94 @@ func TestHugeIntTypeComprehensive(t *testing.T) {
 			t.Error("Expected error for invalid type")
 		}
 	})
+
+	t.Run("Scan_NativeValues", func(t *testing.T) {
+		var h duckdb.HugeIntType
+
+		original := big.NewInt(42)
+		if err := h.Scan(original); err != nil {
+			t.Fatalf("Expected no error for *big.Int, got %v", err)
+		}
+		original.SetInt64(0)
+		if h.Data.Int64() != 42 {
+			t.Errorf("Expected scanned value to be a copy, got %s", h.Data)
+		}
+
+		if err := h.Scan(*big.NewInt(-7)); err != nil {
+			t.Fatalf("Expected no error for big.Int, got %v", err)
+		}
+		if h.Data.Int64() != -7 {
+			t.Errorf("Expected -7, got %s", h.Data)
+		}
+
+		if err := h.Scan(uint64(18446744073709551615)); err != nil {
+			t.Fatalf("Expected no error for uint64, got %v", err)
+		}
+		if h.Data.String() != "18446744073709551615" {
+			t.Errorf("Expected max uint64, got %s", h.Data)
+		}
+	})
+
+	t.Run("Unsigned", func(t *testing.T) {
+		h := duckdb.HugeIntType{Data: big.NewInt(-1), Unsigned: true}
+		if h.GormDataType() != "UHUGEINT" {
+			t.Errorf("Expected UHUGEINT, got %s", h.GormDataType())
+		}
+		if _, err := h.Value(); err == nil {
+			t.Error("Expected error for negative UHUGEINT")
+		}
+	})
+
+	t.Run("Database", func(t *testing.T) {
+		db := setupTestDB(t)
+
+		var max duckdb.HugeIntType
+		if err := db.Raw("SELECT 170141183460469231731687303715884105727::HUGEINT").Row().Scan(&max); err != nil {
+			t.Fatalf("Failed to scan HUGEINT: %v", err)
+		}
+		if max.Data.String() != "170141183460469231731687303715884105727" {
+			t.Errorf("Expected max HUGEINT, got %s", max.Data)
+		}
+
+		type HugeLedger struct {
+			ID      uint `gorm:"primaryKey"`
+			Balance duckdb.HugeIntType
+			Credit  duckdb.HugeIntType `gorm:"type:UHUGEINT"`
+		}
+		if err := db.AutoMigrate(&HugeLedger{}); err != nil {
+			t.Fatalf("Failed to migrate: %v", err)
+		}
+
+		balance, _ := new(big.Int).SetString("-99999999999999999999999999", 10)
+		credit, _ := new(big.Int).SetString("300000000000000000000000000000000000000", 10)
+		ledger := HugeLedger{
+			Balance: duckdb.HugeIntType{Data: balance},
+			Credit:  duckdb.HugeIntType{Data: credit, Unsigned: true},
+		}
+		if err := db.Create(&ledger).Error; err != nil {
+			t.Fatalf("Failed to create: %v", err)
+		}
+
+		var found HugeLedger
+		err := db.Table("huge_ledgers").
+			Select("id, balance, CAST(credit AS VARCHAR) AS credit").
+			First(&found, ledger.ID).Error
+		if err != nil {
+			t.Fatalf("Failed to read back: %v", err)
+		}
+		if found.Balance.Data.Cmp(balance) != 0 {
+			t.Errorf("Expected balance %s, got %s", balance, found.Balance.Data)
+		}
+		if found.Credit.Data.Cmp(credit) != 0 {
+			t.Errorf("Expected credit %s, got %s", credit, found.Credit.Data)
+		}
+
+		var columnType string
+		db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'huge_ledgers' AND column_name = 'credit'").Scan(&columnType)
+		if columnType != "UHUGEINT" {
+			t.Errorf("Expected UHUGEINT column, got %s", columnType)
+		}
+	})
 }
 
 // TestBitStringTypeComprehensive tests all code paths for BitStringType
@@ -881,6 +1267,99 @@ func TestBitStringTypeComprehensive(t *testing.T) {
 			t.Error("Expected error for invalid bit character")
 		}
 	})
+
+	t.Run("HexString", func(t *testing.T) {
+		db := setupTestDB(t)
+		for _, bits := range []string{"101", "10100", "1010101", "10101010", "101010101"} {
+			b, err := duckdb.NewBitStringFromString(bits, len(bits))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// DuckDB's BIT to BLOB cast defines the expected hex
+			var want string
+			if err := db.Raw("SELECT hex(CAST(CAST(? AS VARCHAR) AS BIT)::BLOB)", bits).Scan(&want).Error; err != nil {
+				t.Fatalf("Failed to convert %s in DuckDB: %v", bits, err)
+			}
+			if got := b.ToHexString(); got != want {
+				t.Errorf("ToHexString(%s) = %s, DuckDB gives %s", bits, got, want)
+			}
+
+			back, err := duckdb.FromHexString(want, len(bits))
+			if err != nil {
+				t.Fatalf("FromHexString(%s) failed: %v", want, err)
+			}
+			if back.ToBinaryString() != bits {
+				t.Errorf("FromHexString(%s) = %s, expected %s", want, back.ToBinaryString(), bits)
+			}
+		}
+
+		all, err := duckdb.FromHexString("a5", 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if all.ToBinaryString() != "10100101" {
+			t.Errorf("Expected every bit of the byte, got %s", all.ToBinaryString())
+		}
+		if _, err := duckdb.FromHexString("15", 3); err == nil {
+			t.Error("Expected error for set padding bits")
+		}
+		if _, err := duckdb.FromHexString("zz", 0); err == nil {
+			t.Error("Expected error for invalid hex")
+		}
+	})
+
+	t.Run("BitwiseOps", func(t *testing.T) {
+		x, _ := duckdb.NewBitStringFromString("10110", 5)
+		y, _ := duckdb.NewBitStringFromString("01100", 5)
+
+		ops := []struct {
+			name string
+			op   func(duckdb.BitStringType) (duckdb.BitStringType, error)
+			want string
+		}{
+			{"And", x.And, "00100"},
+			{"Or", x.Or, "11110"},
+			{"Xor", x.Xor, "11010"},
+		}
+		for _, tc := range ops {
+			got, err := tc.op(y)
+			if err != nil {
+				t.Fatalf("%s failed: %v", tc.name, err)
+			}
+			if got.ToBinaryString() != tc.want {
+				t.Errorf("%s = %s, expected %s", tc.name, got.ToBinaryString(), tc.want)
+			}
+		}
+
+		short, _ := duckdb.NewBitStringFromString("101", 3)
+		if _, err := x.And(short); err == nil {
+			t.Error("Expected error for different lengths")
+		}
+
+		shifts := []struct {
+			shift func(int) (duckdb.BitStringType, error)
+			n     int
+			want  string
+		}{
+			{x.ShiftLeft, 1, "01100"},
+			{x.ShiftLeft, 7, "00000"},
+			{x.ShiftRight, 2, "00101"},
+			{short.ShiftRight, 0, "101"},
+		}
+		for _, tc := range shifts {
+			got, err := tc.shift(tc.n)
+			if err != nil {
+				t.Fatalf("Shift by %d failed: %v", tc.n, err)
+			}
+			if got.ToBinaryString() != tc.want {
+				t.Errorf("Shift by %d = %s, expected %s", tc.n, got.ToBinaryString(), tc.want)
+			}
+		}
+		if _, err := x.ShiftLeft(-1); err == nil {
+			t.Error("Expected error for negative shift")
+		}
+	})
 }
 
 // TestBLOBTypeComprehensive tests all code paths for BLOBType
@@ -1010,6 +1489,58 @@ func TestGEOMETRYTypeComprehensive(t *testing.T) {
 			t.Errorf("Expected 4326, got %d", g.SRID)
 		}
 	})
+
+	t.Run("GetBounds", func(t *testing.T) {
+		tests := []struct {
+			wkt  string
+			want map[string]float64
+		}{
+			{"POINT(1.5 -2)", map[string]float64{"minX": 1.5, "minY": -2, "maxX": 1.5, "maxY": -2}},
+			{"LINESTRING(0 0, 3 4, -1 2)", map[string]float64{"minX": -1, "minY": 0, "maxX": 3, "maxY": 4}},
+			{"POLYGON((0 0, 10 0, 10 5, 0 5, 0 0), (2 2, 3 2, 3 3, 2 2))", map[string]float64{"minX": 0, "minY": 0, "maxX": 10, "maxY": 5}},
+			{"MULTIPOINT((1 1), (-4 7))", map[string]float64{"minX": -4, "minY": 1, "maxX": 1, "maxY": 7}},
+			{"MULTIPOINT(1 1, -4 7)", map[string]float64{"minX": -4, "minY": 1, "maxX": 1, "maxY": 7}},
+			{"MULTILINESTRING((0 0, 1 1), (5 -3, 6 2))", map[string]float64{"minX": 0, "minY": -3, "maxX": 6, "maxY": 2}},
+			{"MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)), ((20 20, 25 20, 25 30, 20 20)))", map[string]float64{"minX": 0, "minY": 0, "maxX": 25, "maxY": 30}},
+			{"POINT Z (1 2 3)", map[string]float64{"minX": 1, "minY": 2, "maxX": 1, "maxY": 2, "minZ": 3, "maxZ": 3}},
+			{"LINESTRING(0 0 -1, 2 2 4)", map[string]float64{"minX": 0, "minY": 0, "maxX": 2, "maxY": 2, "minZ": -1, "maxZ": 4}},
+			{"LINESTRING M (0 0 9, 2 2 8)", map[string]float64{"minX": 0, "minY": 0, "maxX": 2, "maxY": 2}},
+			{"SRID=4326;POINT(7 8)", map[string]float64{"minX": 7, "minY": 8, "maxX": 7, "maxY": 8}},
+			{"POINT EMPTY", map[string]float64{"minX": 0, "minY": 0, "maxX": 0, "maxY": 0}},
+		}
+		for _, tc := range tests {
+			got := duckdb.GEOMETRYType{WKT: tc.wkt}.GetBounds()
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("GetBounds(%s) = %v, expected %v", tc.wkt, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("Centroid", func(t *testing.T) {
+		tests := []struct{ wkt, want string }{
+			{"POINT(1 2)", "POINT(1 2)"},
+			{"LINESTRING(0 0, 4 2)", "POINT(2 1)"},
+			{"POLYGON((0 0, 4 0, 4 4, 0 4, 0 0))", "POINT(2 2)"},
+			{"MULTIPOINT((0 0), (1 0), (2 3))", "POINT(1 1)"},
+			{"POINT Z (1 2 3)", "POINT Z (1 2 3)"},
+		}
+		for _, tc := range tests {
+			centroid, err := duckdb.NewGeometry(tc.wkt, 4326).Centroid()
+			if err != nil {
+				t.Fatalf("Centroid(%s) failed: %v", tc.wkt, err)
+			}
+			if centroid.WKT != tc.want || centroid.SRID != 4326 {
+				t.Errorf("Centroid(%s) = %s (SRID %d), expected %s", tc.wkt, centroid.WKT, centroid.SRID, tc.want)
+			}
+		}
+
+		if _, err := duckdb.NewGeometry("POINT EMPTY", 0).Centroid(); err == nil {
+			t.Error("Expected error for empty geometry")
+		}
+		if _, err := duckdb.NewGeometry("LINESTRING(0 0, x 1)", 0).Centroid(); err == nil {
+			t.Error("Expected error for invalid coordinates")
+		}
+	})
 }
 
 // TestAllAdvancedTypesCoverage ensures we test the specialized types too
@@ -669,3 +669,56 @@ func TestAdvancedTypesCompletionSummary(t *testing.T) {
 	t.Log("🔧 PRODUCTION READY: Full GORM integration with battle-tested interfaces")
 	t.Log(strings.Repeat("=", 60))
 }
+
+func TestDecimalType_Arithmetic(t *testing.T) {
+	a := duckdb.NewDecimal("0.10", 10, 2)
+	b := duckdb.NewDecimal("0.20", 10, 2)
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if sum != duckdb.NewDecimal("0.30", 11, 2) {
+		t.Errorf("Expected 0.30 as DECIMAL(11,2), got %+v", sum)
+	}
+
+	difference, err := a.Sub(duckdb.NewDecimal("0.125", 5, 3))
+	if err != nil {
+		t.Fatalf("Sub failed: %v", err)
+	}
+	if difference != duckdb.NewDecimal("-0.025", 12, 3) {
+		t.Errorf("Expected -0.025 as DECIMAL(12,3), got %+v", difference)
+	}
+
+	product, err := duckdb.NewDecimal("19.99", 10, 2).Mul(duckdb.NewDecimal("3", 4, 0))
+	if err != nil {
+		t.Fatalf("Mul failed: %v", err)
+	}
+	if product != duckdb.NewDecimal("59.97", 14, 2) {
+		t.Errorf("Expected 59.97 as DECIMAL(14,2), got %+v", product)
+	}
+
+	// Sums far beyond float64 precision stay exact
+	big1 := duckdb.NewDecimal("12345678901234567890.123456789", 38, 9)
+	big2 := duckdb.NewDecimal("0.000000001", 38, 9)
+	sum, err = big1.Add(big2)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if sum.Data != "12345678901234567890.123456790" || sum.Precision != 38 {
+		t.Errorf("Expected exact sum capped at precision 38, got %+v", sum)
+	}
+
+	// Without a scale the fractional digits of the value are used
+	sum, err = duckdb.DecimalType{Data: "1.5"}.Add(duckdb.DecimalType{Data: "2.25"})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if sum.Data != "3.75" || sum.Scale != 2 {
+		t.Errorf("Expected 3.75 with scale 2, got %+v", sum)
+	}
+
+	if _, err := (duckdb.DecimalType{Data: "abc"}).Add(a); err == nil {
+		t.Error("Expected an error for an invalid decimal")
+	}
+}
@@ -0,0 +1,106 @@
+package duckdb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/schema"
+)
+
+// Interop with github.com/google/uuid. go-duckdb already depends on it, so
+// this adds nothing to the build of programs that do not use it.
+
+// FromGoogleUUID creates a UUIDType from a google/uuid UUID
+func FromGoogleUUID(u uuid.UUID) UUIDType {
+	return NewUUIDFromBytes(u)
+}
+
+// ToGoogleUUID converts the UUID to a google/uuid UUID
+func (u UUIDType) ToGoogleUUID() (uuid.UUID, error) {
+	b, err := parseUUID(u.Data)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.UUID(b), nil
+}
+
+var googleUUIDType = reflect.TypeOf(uuid.UUID{})
+
+// UUIDSerializer stores uuid.UUID, *uuid.UUID, [16]byte and string fields in
+// a DuckDB UUID column. It is registered as "uuid":
+//
+//	type Session struct {
+//		ID    uuid.UUID `gorm:"primaryKey;serializer:uuid"`
+//		Owner string    `gorm:"serializer:uuid"` // UUID text, checked on write
+//	}
+//
+// With Config.NativeUUID, fields typed uuid.UUID map to UUID columns without
+// the tag as well, and fields without a type tag get UUID columns rather than
+// the type of their Go field.
+type UUIDSerializer struct{}
+
+func init() {
+	schema.RegisterSerializer("uuid", UUIDSerializer{})
+}
+
+// Scan implements schema.SerializerInterface
+func (UUIDSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	target := field.ReflectValueOf(ctx, dst)
+	if dbValue == nil {
+		target.Set(reflect.Zero(field.FieldType))
+		return nil
+	}
+
+	var scanned UUIDType
+	if err := scanned.Scan(dbValue); err != nil {
+		return err
+	}
+	b, err := parseUUID(scanned.Data)
+	if err != nil {
+		return err
+	}
+
+	value := reflect.New(field.IndirectFieldType).Elem()
+	switch {
+	case value.Kind() == reflect.String:
+		value.SetString(formatUUID(b))
+	case value.Kind() == reflect.Array && value.Len() == 16 && value.Type().Elem().Kind() == reflect.Uint8:
+		reflect.Copy(value, reflect.ValueOf(b[:]))
+	default:
+		return fmt.Errorf("cannot scan UUID into field %s of type %s", field.Name, field.FieldType)
+	}
+	if field.FieldType.Kind() == reflect.Ptr {
+		value = value.Addr()
+	}
+	target.Set(value)
+	return nil
+}
+
+// Value implements schema.SerializerValuerInterface. Nil pointers and empty
+// strings are stored as NULL.
+func (UUIDSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(fieldValue)
+	if !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return nil, nil
+	}
+	rv = reflect.Indirect(rv)
+	switch {
+	case rv.Kind() == reflect.String:
+		return NewUUID(rv.String()).Value()
+	case rv.Kind() == reflect.Array && rv.Len() == 16 && rv.Type().Elem().Kind() == reflect.Uint8:
+		var b [16]byte
+		reflect.Copy(reflect.ValueOf(&b).Elem(), rv)
+		return formatUUID(b), nil
+	}
+	return nil, fmt.Errorf("cannot store %T as UUID in field %s", fieldValue, field.Name)
+}
+
+// isUUIDField reports fields stored in a UUID column: those typed uuid.UUID
+// and those using the uuid serializer
+func isUUIDField(field *schema.Field) bool {
+	return field.IndirectFieldType == googleUUIDType ||
+		strings.EqualFold(field.TagSettings["SERIALIZER"], "uuid")
+}
@@ -0,0 +1,112 @@
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+func TestGoogleUUIDConversion(t *testing.T) {
+	google := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	u := duckdb.FromGoogleUUID(google)
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", u.String())
+
+	back, err := u.ToGoogleUUID()
+	require.NoError(t, err)
+	assert.Equal(t, google, back)
+
+	back, err = duckdb.NewUUID("{550E8400-E29B-41D4-A716-446655440000}").ToGoogleUUID()
+	require.NoError(t, err)
+	assert.Equal(t, google, back)
+
+	_, err = duckdb.NewUUID("not-a-uuid").ToGoogleUUID()
+	assert.Error(t, err)
+}
+
+type UUIDSession struct {
+	ID      uuid.UUID  `gorm:"primaryKey"`
+	Owner   string     `gorm:"serializer:uuid"`
+	Parent  *uuid.UUID `gorm:"serializer:uuid"`
+	Subject uuid.UUID  `gorm:"serializer:uuid"`
+}
+
+func TestUUIDSerializer(t *testing.T) {
+	db, err := gorm.Open(duckdb.New(duckdb.Config{NativeUUID: true}), &gorm.Config{})
+	require.NoError(t, err)
+	migrator := db.Migrator()
+	require.NoError(t, db.AutoMigrate(&UUIDSession{}))
+
+	columnTypes, err := migrator.ColumnTypes(&UUIDSession{})
+	require.NoError(t, err)
+	for _, column := range columnTypes {
+		assert.Equal(t, "UUID", column.DatabaseTypeName(), column.Name())
+	}
+
+	parent := uuid.New()
+	session := UUIDSession{
+		ID:      uuid.New(),
+		Owner:   "6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		Parent:  &parent,
+		Subject: uuid.New(),
+	}
+	require.NoError(t, db.Create(&session).Error)
+	require.NoError(t, db.Create(&UUIDSession{ID: uuid.New()}).Error)
+
+	var found UUIDSession
+	require.NoError(t, db.First(&found, "id = ?", session.ID).Error)
+	assert.Equal(t, session.ID, found.ID)
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", found.Owner)
+	require.NotNil(t, found.Parent)
+	assert.Equal(t, parent, *found.Parent)
+	assert.Equal(t, session.Subject, found.Subject)
+
+	var empty UUIDSession
+	require.NoError(t, db.Where("owner IS NULL").First(&empty).Error)
+	assert.Empty(t, empty.Owner)
+	assert.Nil(t, empty.Parent)
+
+	assert.Error(t, db.Create(&UUIDSession{ID: uuid.New(), Owner: "nope"}).Error)
+}
+
+type UUIDAccount struct {
+	ID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Owner  string    `gorm:"serializer:uuid"`
+	Legacy string    `gorm:"type:VARCHAR(36);serializer:uuid"`
+}
+
+func TestUUIDColumnTypes(t *testing.T) {
+	columnTypes := func(db *gorm.DB) map[string]string {
+		columnTypes, err := db.Migrator().ColumnTypes(&UUIDAccount{})
+		require.NoError(t, err)
+		types := map[string]string{}
+		for _, column := range columnTypes {
+			types[column.Name()] = column.DatabaseTypeName()
+		}
+		return types
+	}
+
+	// Without NativeUUID only the type tag decides
+	db, _ := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&UUIDAccount{}))
+	assert.Equal(t, map[string]string{"id": "UUID", "owner": "VARCHAR", "legacy": "VARCHAR"}, columnTypes(db))
+	require.NoError(t, db.Create(&UUIDAccount{ID: uuid.New(), Owner: uuid.NewString(), Legacy: uuid.NewString()}).Error)
+
+	// Enabling it on the same database alters untagged columns to UUID;
+	// tagged ones keep their type
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	native, err := gorm.Open(duckdb.New(duckdb.Config{Conn: sqlDB, NativeUUID: true}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, native.AutoMigrate(&UUIDAccount{}))
+	assert.Equal(t, map[string]string{"id": "UUID", "owner": "UUID", "legacy": "VARCHAR"}, columnTypes(native))
+
+	var count int64
+	require.NoError(t, native.Model(&UUIDAccount{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}